@@ -2,7 +2,12 @@ package cmd
 
 import (
 	"fmt"
+	"go/build"
+	"io"
 	"os"
+	"path/filepath"
+	"strings"
+	"time"
 
 	"github.com/gookit/color"
 	"github.com/khulnasoft/go-licenses/golicenses"
@@ -29,17 +34,27 @@ var checkFormatFlag string
 var checkTemplateFileFlag string
 var checkStrictFlag bool
 var checkSummaryFlag bool
+var checkNoticesFileFlag string
+var checkExplainFlag bool
+var checkFailOnFlag string
+var checkBaselineFlag string
+var checkWriteBaselineFlag string
+var checkSinceFlag string
 
 func init() {
-	checkCmd.Flags().StringVar(&checkFormatFlag, "format", "text", "Output format: text, csv, json, markdown, html, spdx, template")
+	checkCmd.Flags().StringVar(&checkFormatFlag, "format", "text", "Output format: text, csv, json, markdown, html, spdx, cyclonedx-json, cyclonedx-xml, sarif, junit, template")
 	checkCmd.Flags().StringVar(&checkTemplateFileFlag, "template-file", "", "Path to Go template file (used only if --format=template)")
 	checkCmd.Flags().BoolVar(&checkStrictFlag, "strict", false, "Fail on unknown or missing licenses")
 	checkCmd.Flags().BoolVar(&checkSummaryFlag, "summary", false, "Print only a summary of license types found")
+	checkCmd.Flags().StringVar(&checkNoticesFileFlag, "notices-file", "", "Also verify that this committed third-party notices file is complete and current")
+	checkCmd.Flags().BoolVar(&checkExplainFlag, "explain", false, "Print the rule (and its scope) that decided each library")
+	checkCmd.Flags().StringVar(&checkFailOnFlag, "fail-on", "error", "Lowest rule violation severity that fails the check: warn, error")
+	checkCmd.Flags().StringVar(&checkBaselineFlag, "baseline", "", "Report only violations that are not in this baseline file (--baseline= for "+golicenses.DefaultBaselineFile+")")
+	checkCmd.Flags().StringVar(&checkWriteBaselineFlag, "write-baseline", "", "Record the current violations in this baseline file (--write-baseline= for "+golicenses.DefaultBaselineFile+")")
+	checkCmd.Flags().StringVar(&checkSinceFlag, "since", "", "Report violations only for modules added, upgraded or with a changed license since this git ref")
 	rootCmd.AddCommand(checkCmd)
 }
 
-// TODO: add to check the ability to check for 3rd party notices are in the repo
-
 // doCheckCmd runs the license check logic for the check command.
 // Now supports --format and --template-file for output customization.
 func doCheckCmd(cmd *cobra.Command, args []string) error {
@@ -48,6 +63,7 @@ func doCheckCmd(cmd *cobra.Command, args []string) error {
 	appConfig.TemplateFile = checkTemplateFileFlag
 	appConfig.Strict = checkStrictFlag
 	appConfig.Summary = checkSummaryFlag
+	resolveBaselineFlags(cmd)
 	if appConfig.Format != "" {
 		appConfig.Output = appConfig.Format // Ensure Output is set for presenter.ParseOption in config.Build()
 	}
@@ -56,17 +72,33 @@ func doCheckCmd(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("config error: %w", err)
 	}
 
-	ruleAction := appConfig.Action()
-	rulePatterns := appConfig.Patterns()
+	ruleList, err := appConfig.RuleList()
+	if err != nil {
+		return fmt.Errorf("could not parse rules: %w", err)
+	}
+	if len(ruleList) == 0 {
+		return fmt.Errorf("no rules configured (permit, forbid, permit-types, forbid-types or rules must be set in .golicenses.yaml or via flags not yet implemented)")
+	}
+	ruleMatch, err := golicenses.ParseMatchMode(appConfig.RuleMatch)
+	if err != nil {
+		return fmt.Errorf("could not parse rules: %w", err)
+	}
 
-	if ruleAction == golicenses.UnknownAction {
-		return fmt.Errorf("no rules configured (permit or forbid must be set in .golicenses.yaml or via flags not yet implemented)")
+	failOn, err := golicenses.ParseSeverity(checkFailOnFlag)
+	if err != nil {
+		return fmt.Errorf("bad --fail-on value: %w", err)
 	}
 
-	rules, err := golicenses.NewRules(ruleAction, rulePatterns, appConfig.IgnorePkg...)
+	rules, err := golicenses.NewOrderedRules(ruleMatch, ruleList, appConfig.IgnorePkg...)
 	if err != nil {
 		return fmt.Errorf("could not parse rules: %w", err)
 	}
+	if rules.Exceptions, err = golicenses.NewExceptions(appConfig.Exceptions...); err != nil {
+		return fmt.Errorf("could not parse exceptions: %w", err)
+	}
+	now := time.Now()
+	window := time.Duration(appConfig.ExceptionExpiryWarning) * 24 * time.Hour
+	warnExpiringExceptions(os.Stderr, now, golicenses.ExpiringExceptions(rules.Exceptions, now, window))
 
 	var paths []string
 	if len(args) > 0 {
@@ -74,7 +106,7 @@ func doCheckCmd(cmd *cobra.Command, args []string) error {
 	} else {
 		paths = []string{"."}
 	}
-	licenseFinder := golicenses.NewLicenseFinder(paths, gitRemotes, 0.9)
+	licenseFinder := newLicenseFinder(paths)
 
 	rawResultsChan, err := licenseFinder.Find()
 	if err != nil {
@@ -83,11 +115,26 @@ func doCheckCmd(cmd *cobra.Command, args []string) error {
 
 	// Collect results
 	var collectedResults []golicenses.LicenseResult
-	var unknownLicenseLibraries []string
-	licenseSummary := make(map[string]int)
-
 	for res := range rawResultsChan {
 		collectedResults = append(collectedResults, res)
+	}
+
+	// with --since, only the changed results are checked strictly, summarized and reported
+	reportedResults := collectedResults
+	var changed map[string]bool
+	if checkSinceFlag != "" {
+		if reportedResults, err = changedSince(checkSinceFlag, packageDir(paths), collectedResults); err != nil {
+			return err
+		}
+		changed = make(map[string]bool, len(reportedResults))
+		for _, res := range reportedResults {
+			changed[res.Library] = true
+		}
+	}
+
+	var unknownLicenseLibraries []string
+	licenseSummary := make(map[string]int)
+	for _, res := range reportedResults {
 		licenseKey := res.License
 		if licenseKey == "" {
 			licenseKey = "Unknown"
@@ -103,11 +150,46 @@ func doCheckCmd(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("strict mode: found unknown/missing licenses for libraries: %v", unknownLicenseLibraries)
 	}
 
+	var noticesErr error
+	if checkNoticesFileFlag != "" {
+		noticesErr = checkNoticesFile(checkNoticesFileFlag, collectedResults)
+	}
+
+	warnUnusedIgnores(os.Stderr, rules.UnusedIgnores(collectedResults...))
+
+	if checkExplainFlag {
+		for _, res := range reportedResults {
+			fmt.Fprintln(os.Stderr, rules.Explain(res, now))
+		}
+	}
+
 	// Evaluate rules against all collected results
-	allowed, violations, err := rules.Evaluate(collectedResults...)
+	ruleViolations, err := rules.ViolationsAt(now, collectedResults...)
 	if err != nil {
 		return fmt.Errorf("error evaluating rules: %w", err)
 	}
+	if ruleViolations, err = applyBaseline(os.Stderr, ruleViolations, changed); err != nil {
+		return err
+	}
+	reportedResults = golicenses.AnnotateResults(reportedResults, ruleViolations)
+	var denied, warnings []golicenses.Violation
+	failing := make(map[string]bool)
+	for _, v := range ruleViolations {
+		if v.Severity < failOn {
+			warnings = append(warnings, v)
+			continue
+		}
+		denied = append(denied, v)
+		failing[v.Result.Library] = true
+	}
+	var violations []golicenses.LicenseResult
+	for _, res := range reportedResults {
+		if failing[res.Library] {
+			violations = append(violations, res)
+		}
+	}
+	allowed := len(denied) == 0
+	warnViolations(os.Stderr, warnings)
 
 	if appConfig.Summary {
 		fmt.Println("License Summary:")
@@ -116,14 +198,15 @@ func doCheckCmd(cmd *cobra.Command, args []string) error {
 		}
 		// If not allowed (rules violated), return an error to indicate failure
 		if !allowed {
+			reportViolations(os.Stderr, denied)
 			return fmt.Errorf("license rule violations detected (summary mode). Problematic licenses for: %v", getLibrariesFromResults(violations))
 		}
-		return nil // Summary printed, and no rule violations or strict failures
+		return noticesErr // Summary printed, and no rule violations or strict failures
 	}
 
 	// If not summary mode, proceed with the standard presenter
 	// Create a new channel from the collected (and potentially filtered for violations by presenter) results
-	resultStreamForPresenter := make(chan golicenses.LicenseResult, len(collectedResults))
+	resultStreamForPresenter := make(chan golicenses.LicenseResult, len(reportedResults))
 	go func() {
 		defer close(resultStreamForPresenter)
 		// Presenters like 'text' often show only violations. If rules passed, it might show nothing.
@@ -134,7 +217,7 @@ func doCheckCmd(cmd *cobra.Command, args []string) error {
 		// Let's stick to sending all results and let the presenter decide, or refine presenter later.
 		// For now, to ensure 'text' presenter shows violations, we pass 'violations' if not allowed.
 		// This is a bit of a hack; presenters should ideally be more aware of 'check' context.
-		resultsToPresent := collectedResults
+		resultsToPresent := reportedResults
 		if !allowed && appConfig.PresenterOpt == presenter.TextPresenter { // Special handling for text presenter to show violations
 			resultsToPresent = violations
 		}
@@ -164,8 +247,190 @@ func doCheckCmd(cmd *cobra.Command, args []string) error {
 
 	// If rules were violated, return an error to ensure check command fails
 	if !allowed {
+		reportViolations(os.Stderr, denied)
 		return fmt.Errorf("license rule violations detected. Problematic licenses for: %v", getLibrariesFromResults(violations))
 	}
 
-	return nil
+	return noticesErr
+}
+
+// packageDir returns the directory of the first package pattern that is a
+// local path (e.g. "./cmd/..."), or the working directory.
+func packageDir(paths []string) string {
+	for _, p := range paths {
+		dir := filepath.ToSlash(p)
+		if dir == "..." {
+			return "."
+		}
+		dir = strings.TrimSuffix(dir, "/...")
+		if build.IsLocalImport(dir) || filepath.IsAbs(p) {
+			return filepath.FromSlash(dir)
+		}
+	}
+	return "."
+}
+
+// changedSince returns the results whose module was added, upgraded or
+// changed its license since a git ref of the repository containing dir.
+func changedSince(ref, dir string, results []golicenses.LicenseResult) ([]golicenses.LicenseResult, error) {
+	base, err := golicenses.ReadGitBase(dir, ref)
+	if err != nil {
+		return nil, err
+	}
+	var changed []golicenses.LicenseResult
+	for _, res := range results {
+		kind, description, err := base.Change(res)
+		if err != nil {
+			return nil, fmt.Errorf("unable to compare %s with %s: %w", res.Library, ref, err)
+		}
+		if kind == golicenses.Unchanged {
+			continue
+		}
+		if appConfig.Verbose > 0 {
+			fmt.Fprintf(os.Stderr, "%s: %s\n", res.Library, description)
+		}
+		changed = append(changed, res)
+	}
+	fmt.Fprintf(os.Stderr, "Checking %d of %d libraries changed since %s\n", len(changed), len(results), ref)
+	return changed, nil
+}
+
+// resolveBaselineFlags sets the baseline flags given an empty value (e.g.
+// --baseline=) to the default baseline file.
+func resolveBaselineFlags(cmd *cobra.Command) {
+	if checkBaselineFlag == "" && cmd.Flags().Changed("baseline") {
+		checkBaselineFlag = golicenses.DefaultBaselineFile
+	}
+	if checkWriteBaselineFlag == "" && cmd.Flags().Changed("write-baseline") {
+		checkWriteBaselineFlag = golicenses.DefaultBaselineFile
+	}
+}
+
+// applyBaseline records the violations of all results with --write-baseline,
+// and drops the violations recorded in the --baseline file. Of the remaining
+// violations, only those of the changed libraries are kept, unless changed
+// is nil.
+func applyBaseline(w io.Writer, violations []golicenses.Violation, changed map[string]bool) ([]golicenses.Violation, error) {
+	if checkWriteBaselineFlag != "" {
+		baseline := golicenses.NewBaseline(violations)
+		if err := writeBaseline(checkWriteBaselineFlag, baseline); err != nil {
+			return nil, err
+		}
+		fmt.Fprintf(w, "Wrote %d violations to %s\n", len(baseline.Violations), checkWriteBaselineFlag)
+		violations, _ = baseline.Filter(violations)
+	}
+	if checkBaselineFlag != "" {
+		baseline, err := golicenses.ReadBaseline(checkBaselineFlag)
+		if err != nil {
+			return nil, err
+		}
+		var removable []golicenses.BaselineEntry
+		violations, removable = baseline.Filter(violations)
+		for _, e := range removable {
+			fmt.Fprintf(w, "baseline entry no longer occurs and can be removed from %s: %s\n", checkBaselineFlag, e)
+		}
+	}
+	if changed == nil {
+		return violations, nil
+	}
+	reported := make([]golicenses.Violation, 0, len(violations))
+	for _, v := range violations {
+		if changed[v.Result.Library] {
+			reported = append(reported, v)
+		}
+	}
+	return reported, nil
+}
+
+func writeBaseline(path string, baseline golicenses.Baseline) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	err = baseline.Write(f)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
+
+// reportViolations prints each violation with the kind of rule that caught it.
+func reportViolations(w io.Writer, violations []golicenses.Violation) {
+	for _, v := range violations {
+		fmt.Fprintf(w, "  %s\n", v)
+	}
+}
+
+// warnUnusedIgnores points out ignore-packages entries that can be removed,
+// or that need a new review because the module version changed.
+func warnUnusedIgnores(w io.Writer, ignores []golicenses.IgnorePkg) {
+	for _, i := range ignores {
+		if i.HasVersions() {
+			fmt.Fprintf(w, "warning: ignore entry %q matches no scanned module version, please review the current version\n", i)
+		} else {
+			fmt.Fprintf(w, "warning: ignore entry %q matches no scanned library\n", i)
+		}
+	}
+}
+
+// warnViolations prints the violations below the --fail-on severity.
+func warnViolations(w io.Writer, violations []golicenses.Violation) {
+	for _, v := range violations {
+		fmt.Fprintf(w, "%s: %s\n", severityLabel(v.Severity), v)
+	}
+}
+
+func severityLabel(s golicenses.Severity) string {
+	if s == golicenses.WarnSeverity {
+		return "warning"
+	}
+	return s.String()
+}
+
+// warnExpiringExceptions asks for a review of exceptions that expire soon or
+// have expired, since their violations fail the check again.
+func warnExpiringExceptions(w io.Writer, now time.Time, exceptions []golicenses.Exception) {
+	for _, e := range exceptions {
+		if e.Expired(now) {
+			fmt.Fprintf(w, "warning: policy exception has expired, its violations are no longer accepted: %s\n", e)
+		} else {
+			fmt.Fprintf(w, "warning: policy exception expires soon, please review: %s\n", e)
+		}
+	}
+}
+
+// checkNoticesFile verifies that a committed third-party notices file covers
+// the current dependencies and prints what needs regenerating.
+func checkNoticesFile(path string, results []golicenses.LicenseResult) error {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("unable to read notices file: %w", err)
+	}
+	doc, err := golicenses.BuildAttribution(results...)
+	if err != nil {
+		return err
+	}
+	problems := golicenses.VerifyAttribution(doc, string(content))
+	if len(problems) == 0 {
+		return nil
+	}
+
+	fmt.Fprintf(os.Stderr, "%s is out of date, regenerate it with 'golicenses notices generate':\n", path)
+	for _, p := range problems {
+		marker := "+"
+		if p.Kind == golicenses.StaleVersion {
+			marker = "~"
+		}
+		fmt.Fprintf(os.Stderr, "  %s %s\n", marker, p)
+	}
+	return fmt.Errorf("notices file %s is missing or has stale entries for %d libraries", path, countEntries(problems))
+}
+
+// countEntries counts the distinct library entries with problems.
+func countEntries(problems []golicenses.AttributionProblem) int {
+	entries := make(map[string]bool)
+	for _, p := range problems {
+		entries[p.Entry] = true
+	}
+	return len(entries)
 }
@@ -1,8 +1,14 @@
 package cmd
 
 import (
+	"bytes"
+	"io"
 	"os/exec"
+	"path/filepath"
+	"reflect"
 	"testing"
+
+	"github.com/khulnasoft/go-licenses/golicenses"
 )
 
 func TestCheckCmd_NoRulesConfigured(t *testing.T) {
@@ -23,3 +29,110 @@ func contains(s, substr string) bool {
 }
 
 // TODO: Add more table-driven tests for check command with different rule sets and input projects.
+
+func TestCheckCmd_BaselineFlags(t *testing.T) {
+	tests := []struct {
+		name          string
+		args          []string
+		baseline      string
+		writeBaseline string
+		paths         []string
+	}{
+		{name: "space separated", args: []string{"--write-baseline", "out.json", "./..."}, writeBaseline: "out.json", paths: []string{"./..."}},
+		{name: "equals", args: []string{"--baseline=base.json", "./..."}, baseline: "base.json", paths: []string{"./..."}},
+		{name: "default file", args: []string{"--baseline=", "--write-baseline=", "./..."}, baseline: golicenses.DefaultBaselineFile, writeBaseline: golicenses.DefaultBaselineFile, paths: []string{"./..."}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			flags := checkCmd.Flags()
+			t.Cleanup(func() {
+				for _, name := range []string{"baseline", "write-baseline"} {
+					if err := flags.Set(name, ""); err != nil {
+						t.Fatal(err)
+					}
+					flags.Lookup(name).Changed = false
+				}
+			})
+			if err := flags.Parse(test.args); err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+			resolveBaselineFlags(checkCmd)
+			if checkBaselineFlag != test.baseline || checkWriteBaselineFlag != test.writeBaseline {
+				t.Errorf("baseline = %q, write-baseline = %q, want %q, %q", checkBaselineFlag, checkWriteBaselineFlag, test.baseline, test.writeBaseline)
+			}
+			if !reflect.DeepEqual(flags.Args(), test.paths) {
+				t.Errorf("args = %v, want %v", flags.Args(), test.paths)
+			}
+		})
+	}
+}
+
+func TestCheckCmd_BaselineSince(t *testing.T) {
+	violation := func(module, license string) golicenses.Violation {
+		res := golicenses.LicenseResult{Library: module, Module: module, Version: "v1.0.0", License: license}
+		return golicenses.Violation{Result: res, Kind: golicenses.LicenseRule, Action: golicenses.DenyAction, Rule: license, Severity: golicenses.ErrorSeverity}
+	}
+	unchangedBaselined := violation("github.com/foo/a", "GPL-2.0")
+	changedBaselined := violation("github.com/foo/b", "GPL-2.0")
+	changedNew := violation("github.com/foo/c", "GPL-3.0")
+	unchangedNew := violation("github.com/foo/d", "AGPL-3.0")
+	violations := []golicenses.Violation{unchangedBaselined, changedBaselined, changedNew, unchangedNew}
+	changed := map[string]bool{"github.com/foo/b": true, "github.com/foo/c": true}
+
+	dir := t.TempDir()
+	baselinePath := filepath.Join(dir, "baseline.json")
+	stale := golicenses.BaselineEntry{Module: "github.com/foo/e", Version: "v1.0.0", License: "GPL-2.0"}
+	baseline := golicenses.NewBaseline([]golicenses.Violation{unchangedBaselined, changedBaselined})
+	baseline.Violations = append(baseline.Violations, stale)
+	if err := writeBaseline(baselinePath, baseline); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		checkBaselineFlag, checkWriteBaselineFlag = "", ""
+	})
+
+	checkBaselineFlag = baselinePath
+	var out bytes.Buffer
+	reported, err := applyBaseline(&out, violations, changed)
+	if err != nil {
+		t.Fatalf("applyBaseline() error = %v", err)
+	}
+	if !reflect.DeepEqual(reported, []golicenses.Violation{changedNew}) {
+		t.Errorf("reported %v, want only %v", reported, changedNew)
+	}
+	want := "baseline entry no longer occurs and can be removed from " + baselinePath + ": " + stale.String() + "\n"
+	if out.String() != want {
+		t.Errorf("output = %q, want %q", out.String(), want)
+	}
+
+	checkBaselineFlag = ""
+	checkWriteBaselineFlag = filepath.Join(dir, "written.json")
+	if _, err := applyBaseline(io.Discard, violations, changed); err != nil {
+		t.Fatalf("applyBaseline() error = %v", err)
+	}
+	written, err := golicenses.ReadBaseline(checkWriteBaselineFlag)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(written, golicenses.NewBaseline(violations)) {
+		t.Errorf("wrote %v, want the violations of all modules", written.Violations)
+	}
+}
+
+func TestPackageDir(t *testing.T) {
+	tests := []struct {
+		paths []string
+		want  string
+	}{
+		{paths: nil, want: "."},
+		{paths: []string{"./..."}, want: "."},
+		{paths: []string{"..."}, want: "."},
+		{paths: []string{"github.com/foo/bar", "../other/..."}, want: filepath.FromSlash("../other")},
+		{paths: []string{"./sub/cmd"}, want: filepath.FromSlash("./sub/cmd")},
+	}
+	for _, test := range tests {
+		if got := packageDir(test.paths); got != test.want {
+			t.Errorf("packageDir(%v) = %q, want %q", test.paths, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,75 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/khulnasoft/go-licenses/golicenses"
+	"github.com/spf13/cobra"
+)
+
+// compareCmd represents the compare-classifiers command
+var compareCmd = &cobra.Command{
+	Use:   "compare-classifiers [path...]",
+	Short: "Report libraries whose license the classifier backends identify differently",
+	Long: `Identify the license of every dependency with each classifier backend and list the
+libraries where the backends disagree on the license name or type.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		err := doCompareCmd(cmd, args)
+		if err != nil {
+			fmt.Println(err.Error())
+			os.Exit(1)
+		}
+	},
+}
+
+var compareClassifiersFlag []string
+
+func init() {
+	compareCmd.Flags().StringSliceVar(&compareClassifiersFlag, "classifiers", nil, "Classifier backends to compare (default: all configured backends)")
+	rootCmd.AddCommand(compareCmd)
+}
+
+func doCompareCmd(cmd *cobra.Command, args []string) error {
+	paths := args
+	if len(paths) == 0 {
+		paths = []string{"."}
+	}
+	backends := compareClassifiersFlag
+	if len(backends) == 0 {
+		backends = []string{golicenses.GoogleClassifier, golicenses.LicensecheckClassifier}
+		if len(appConfig.ClassifierPlugin.Command) > 0 {
+			backends = append(backends, golicenses.PluginClassifier)
+		}
+	}
+	disagreements, err := newLicenseFinder(paths).CompareClassifiers(backends...)
+	if err != nil {
+		return err
+	}
+	return writeDisagreements(os.Stdout, backends, disagreements)
+}
+
+// writeDisagreements prints a table with the classification of each backend.
+func writeDisagreements(w io.Writer, backends []string, disagreements []golicenses.ClassifierDisagreement) error {
+	if len(disagreements) == 0 {
+		_, err := fmt.Fprintf(w, "All classifiers agree (%s)\n", strings.Join(backends, ", "))
+		return err
+	}
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(tw, "PACKAGE\t%s\n", strings.ToUpper(strings.Join(backends, "\t")))
+	for _, d := range disagreements {
+		cells := make([]string, len(d.Classifications))
+		for i, c := range d.Classifications {
+			if c.Errs != nil {
+				cells[i] = fmt.Sprintf("error: %v", c.Errs)
+			} else {
+				cells[i] = fmt.Sprintf("%s (%s)", c.License, c.Type)
+			}
+		}
+		fmt.Fprintf(tw, "%s\t%s\n", d.Library, strings.Join(cells, "\t"))
+	}
+	return tw.Flush()
+}
@@ -0,0 +1,156 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/khulnasoft/go-licenses/golicenses"
+	"github.com/khulnasoft/go-licenses/golicenses/licenses"
+	"github.com/spf13/cobra"
+)
+
+// dbCmd represents the db command
+var dbCmd = &cobra.Command{
+	Use:   "db",
+	Short: "Inspect or build the license database used by the classifier",
+}
+
+var dbInfoCmd = &cobra.Command{
+	Use:   "info",
+	Short: "Show the version and content summary of the license database",
+	Run: func(cmd *cobra.Command, args []string) {
+		err := doDBInfoCmd(cmd, args)
+		if err != nil {
+			fmt.Println(err.Error())
+			os.Exit(1)
+		}
+	},
+}
+
+var dbListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the licenses known by the license database",
+	Run: func(cmd *cobra.Command, args []string) {
+		err := doDBListCmd(cmd, args)
+		if err != nil {
+			fmt.Println(err.Error())
+			os.Exit(1)
+		}
+	},
+}
+
+var dbBuildCmd = &cobra.Command{
+	Use:   "build",
+	Short: "Build a license database from SPDX license texts",
+	Long: `Build a license database from a directory of license texts named <license>.txt,
+like the text directory of https://github.com/spdx/license-list-data.
+Use the result with --license-db (or license-db in .golicenses.yaml).`,
+	Run: func(cmd *cobra.Command, args []string) {
+		err := doDBBuildCmd(cmd, args)
+		if err != nil {
+			fmt.Println(err.Error())
+			os.Exit(1)
+		}
+	},
+}
+
+var dbBuildFromFlag string
+var dbBuildFileFlag string
+var dbBuildVersionFlag string
+
+func init() {
+	dbBuildCmd.Flags().StringVar(&dbBuildFromFlag, "from", "", "Directory of license texts (<license>.txt)")
+	dbBuildCmd.Flags().StringVar(&dbBuildFileFlag, "file", "licenses.db", "Path of the license database to write")
+	dbBuildCmd.Flags().StringVar(&dbBuildVersionFlag, "list-version", "", "License list version to record (default: read from license-list-data's licenses.json)")
+	_ = dbBuildCmd.MarkFlagRequired("from")
+	dbCmd.AddCommand(dbInfoCmd, dbListCmd, dbBuildCmd)
+	rootCmd.AddCommand(dbCmd)
+}
+
+// readDBInfo reads the license database selected by --license-db.
+func readDBInfo() (licenses.DBInfo, error) {
+	archive, err := golicenses.LicenseDBArchiveFetcher(appConfig.LicenseDB)()
+	if err != nil {
+		return licenses.DBInfo{}, err
+	}
+	return licenses.ReadDBInfo(archive)
+}
+
+func doDBInfoCmd(cmd *cobra.Command, args []string) error {
+	info, err := readDBInfo()
+	if err != nil {
+		return err
+	}
+	return writeDBInfo(os.Stdout, appConfig.LicenseDB, info)
+}
+
+// writeDBInfo prints where the database comes from, its version and how many licenses of each type it knows.
+func writeDBInfo(w io.Writer, path string, info licenses.DBInfo) error {
+	if path == "" {
+		path = "embedded"
+	}
+	version, created := info.Version, "unknown"
+	if version == "" {
+		version = "unknown"
+	}
+	if !info.Created.IsZero() {
+		created = info.Created.Format("2006-01-02T15:04:05Z07:00")
+	}
+
+	typeCounts := make(map[string]int)
+	for _, l := range info.Licenses {
+		typeCounts[l.Type.String()]++
+	}
+	types := make([]string, 0, len(typeCounts))
+	for t := range typeCounts {
+		types = append(types, t)
+	}
+	sort.Strings(types)
+	counts := make([]string, len(types))
+	for i, t := range types {
+		counts[i] = fmt.Sprintf("%s: %d", t, typeCounts[t])
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 1, ' ', 0)
+	fmt.Fprintf(tw, "Source:\t%s\n", path)
+	fmt.Fprintf(tw, "Version:\t%s\n", version)
+	fmt.Fprintf(tw, "Created:\t%s\n", created)
+	fmt.Fprintf(tw, "Size:\t%d bytes\n", info.Size)
+	fmt.Fprintf(tw, "SHA256:\t%s\n", info.SHA256)
+	fmt.Fprintf(tw, "Licenses:\t%d (%s)\n", len(info.Licenses), strings.Join(counts, ", "))
+	return tw.Flush()
+}
+
+func doDBListCmd(cmd *cobra.Command, args []string) error {
+	info, err := readDBInfo()
+	if err != nil {
+		return err
+	}
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "LICENSE\tTYPE")
+	for _, l := range info.Licenses {
+		fmt.Fprintf(tw, "%s\t%s\n", l.Name, l.Type)
+	}
+	return tw.Flush()
+}
+
+func doDBBuildCmd(cmd *cobra.Command, args []string) error {
+	f, err := os.Create(dbBuildFileFlag)
+	if err != nil {
+		return err
+	}
+	count, err := licenses.BuildDB(f, dbBuildFromFlag, dbBuildVersionFlag)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		_ = os.Remove(dbBuildFileFlag)
+		return err
+	}
+	fmt.Printf("Wrote %d licenses to %s\n", count, dbBuildFileFlag)
+	return nil
+}
@@ -0,0 +1,210 @@
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	encjson "encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/khulnasoft/go-licenses/golicenses"
+	"github.com/khulnasoft/go-licenses/golicenses/presenter/csv"
+	"github.com/khulnasoft/go-licenses/golicenses/presenter/json"
+	"github.com/spf13/cobra"
+)
+
+// diffCmd represents the diff command
+var diffCmd = &cobra.Command{
+	Use:   "diff old-report new-report",
+	Short: "Compare two license reports",
+	Long: `Compare two license reports written by the json (or csv) output format and list the
+modules that were added or removed, and version, license and type changes.`,
+	Args: cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		err := doDiffCmd(cmd, args)
+		if err != nil {
+			fmt.Println(err.Error())
+			os.Exit(1)
+		}
+	},
+}
+
+var diffFormatFlag string
+
+func init() {
+	diffCmd.Flags().StringVar(&diffFormatFlag, "format", "text", "Output format: text, markdown, json")
+	rootCmd.AddCommand(diffCmd)
+}
+
+func doDiffCmd(cmd *cobra.Command, args []string) error {
+	old, err := readReport(args[0])
+	if err != nil {
+		return err
+	}
+	new, err := readReport(args[1])
+	if err != nil {
+		return err
+	}
+	diff := golicenses.DiffReports(old, new)
+
+	switch strings.ToLower(diffFormatFlag) {
+	case "text":
+		return writeDiffText(os.Stdout, diff)
+	case "markdown":
+		return writeDiffMarkdown(os.Stdout, diff)
+	case "json":
+		return writeDiffJSON(os.Stdout, diff)
+	default:
+		return fmt.Errorf("bad --format value '%s' (options=text, markdown, json)", diffFormatFlag)
+	}
+}
+
+// readReport reads a JSON report, or a CSV report if the file does not start with a JSON array.
+func readReport(path string) ([]golicenses.LicenseResult, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var results []golicenses.LicenseResult
+	if trimmed := bytes.TrimSpace(content); len(trimmed) > 0 && trimmed[0] == '[' {
+		results, err = json.ReadResults(bytes.NewReader(content))
+	} else {
+		results, err = csv.ReadResults(bytes.NewReader(content))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("unable to read report %s: %w", path, err)
+	}
+	return results, nil
+}
+
+// changeText describes the changed attributes of a module, e.g. "v1.0.0 -> v1.1.0, license MIT -> Apache-2.0".
+func changeText(c golicenses.ModuleChange) string {
+	var changes []string
+	if c.VersionChanged() {
+		changes = append(changes, fmt.Sprintf("%s -> %s", orNone(c.Old.Version), orNone(c.New.Version)))
+	}
+	if c.LicenseChanged() {
+		changes = append(changes, fmt.Sprintf("license %s -> %s", orNone(c.Old.License), orNone(c.New.License)))
+	}
+	if c.TypeChanged() {
+		changes = append(changes, fmt.Sprintf("type %s -> %s", orNone(c.Old.Type), orNone(c.New.Type)))
+	}
+	return strings.Join(changes, ", ")
+}
+
+func orNone(s string) string {
+	if s == "" {
+		return "none"
+	}
+	return s
+}
+
+func moduleText(m golicenses.ReportModule) string {
+	module := m.Module
+	if m.Version != "" {
+		module += " " + m.Version
+	}
+	return fmt.Sprintf("%s: %s (%s)", module, orNone(m.License), orNone(m.Type))
+}
+
+func writeDiffText(w io.Writer, diff golicenses.ReportDiff) error {
+	bw := bufio.NewWriter(w)
+	if diff.Empty() {
+		fmt.Fprintln(bw, "No changes")
+	}
+	for _, m := range diff.Added {
+		fmt.Fprintf(bw, "+ %s\n", moduleText(m))
+	}
+	for _, m := range diff.Removed {
+		fmt.Fprintf(bw, "- %s\n", moduleText(m))
+	}
+	for _, c := range diff.Changed {
+		fmt.Fprintf(bw, "~ %s: %s\n", c.New.Module, changeText(c))
+	}
+	return bw.Flush()
+}
+
+func writeDiffMarkdown(w io.Writer, diff golicenses.ReportDiff) error {
+	bw := bufio.NewWriter(w)
+	fmt.Fprintf(bw, "## License Changes\n\n")
+	if diff.Empty() {
+		fmt.Fprintln(bw, "No changes.")
+		return bw.Flush()
+	}
+	writeModuleTable := func(title string, modules []golicenses.ReportModule) {
+		if len(modules) == 0 {
+			return
+		}
+		fmt.Fprintf(bw, "### %s\n\n| Module | Version | License | Type |\n| --- | --- | --- | --- |\n", title)
+		for _, m := range modules {
+			fmt.Fprintf(bw, "| %s | %s | %s | %s |\n", m.Module, m.Version, m.License, m.Type)
+		}
+		fmt.Fprintln(bw)
+	}
+	writeModuleTable("Added", diff.Added)
+	writeModuleTable("Removed", diff.Removed)
+	if len(diff.Changed) > 0 {
+		fmt.Fprintf(bw, "### Changed\n\n| Module | Version | License | Type |\n| --- | --- | --- | --- |\n")
+		for _, c := range diff.Changed {
+			fmt.Fprintf(bw, "| %s | %s | %s | %s |\n", c.New.Module,
+				changedCell(c.Old.Version, c.New.Version),
+				changedCell(c.Old.License, c.New.License),
+				changedCell(c.Old.Type, c.New.Type))
+		}
+		fmt.Fprintln(bw)
+	}
+	return bw.Flush()
+}
+
+// changedCell shows "old → new" in bold for changed values.
+func changedCell(old, new string) string {
+	if old == new {
+		return new
+	}
+	return fmt.Sprintf("**%s → %s**", orNone(old), orNone(new))
+}
+
+type jsonDiffModule struct {
+	Module  string `json:"module"`
+	Version string `json:"version,omitempty"`
+	License string `json:"license"`
+	Type    string `json:"type"`
+}
+
+type jsonDiffChange struct {
+	Module string         `json:"module"`
+	Old    jsonDiffModule `json:"old"`
+	New    jsonDiffModule `json:"new"`
+}
+
+type jsonDiff struct {
+	Added   []jsonDiffModule `json:"added"`
+	Removed []jsonDiffModule `json:"removed"`
+	Changed []jsonDiffChange `json:"changed"`
+}
+
+func writeDiffJSON(w io.Writer, diff golicenses.ReportDiff) error {
+	toJSON := func(m golicenses.ReportModule) jsonDiffModule {
+		return jsonDiffModule{Module: m.Module, Version: m.Version, License: m.License, Type: m.Type}
+	}
+	doc := jsonDiff{
+		Added:   make([]jsonDiffModule, 0, len(diff.Added)),
+		Removed: make([]jsonDiffModule, 0, len(diff.Removed)),
+		Changed: make([]jsonDiffChange, 0, len(diff.Changed)),
+	}
+	for _, m := range diff.Added {
+		doc.Added = append(doc.Added, toJSON(m))
+	}
+	for _, m := range diff.Removed {
+		doc.Removed = append(doc.Removed, toJSON(m))
+	}
+	for _, c := range diff.Changed {
+		doc.Changed = append(doc.Changed, jsonDiffChange{Module: c.New.Module, Old: toJSON(c.Old), New: toJSON(c.New)})
+	}
+	enc := encjson.NewEncoder(w)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	return enc.Encode(doc)
+}
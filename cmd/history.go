@@ -0,0 +1,212 @@
+package cmd
+
+import (
+	"bufio"
+	encjson "encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/khulnasoft/go-licenses/golicenses"
+	"github.com/spf13/cobra"
+)
+
+// historyCmd represents the history command
+var historyCmd = &cobra.Command{
+	Use:   "history [module]",
+	Short: "Show when dependencies were added and when their license last changed",
+	Long: `Walk the git history of go.mod and go.sum on the current branch and show, for each
+dependency (or the given module), the commit and author that first required it, and the
+last commit that changed its license. Licenses of past versions are identified from the
+module cache; use --download to fetch versions that are missing from it.`,
+	Args: cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		err := doHistoryCmd(cmd, args)
+		if err != nil {
+			fmt.Println(err.Error())
+			os.Exit(1)
+		}
+	},
+}
+
+var historyFormatFlag string
+var historyDownloadFlag bool
+
+func init() {
+	historyCmd.Flags().StringVar(&historyFormatFlag, "format", "text", "Output format: text, json")
+	historyCmd.Flags().BoolVar(&historyDownloadFlag, "download", false, "Download module versions missing from the module cache")
+	rootCmd.AddCommand(historyCmd)
+}
+
+func doHistoryCmd(cmd *cobra.Command, args []string) error {
+	format := strings.ToLower(historyFormatFlag)
+	if format != "text" && format != "json" {
+		return fmt.Errorf("bad --format value '%s' (options=text, json)", historyFormatFlag)
+	}
+
+	results, err := findResults(nil)
+	if err != nil {
+		return err
+	}
+	results = moduleResults(results)
+	if len(args) > 0 {
+		var selected []golicenses.LicenseResult
+		for _, res := range results {
+			if res.Module == args[0] || res.Library == args[0] {
+				selected = append(selected, res)
+			}
+		}
+		if len(selected) == 0 {
+			return fmt.Errorf("%s is not a module of this project", args[0])
+		}
+		results = selected
+	}
+
+	history, err := golicenses.ReadHistory(".")
+	if err != nil {
+		return err
+	}
+	classifier, err := newLicenseFinder(nil).NewClassifier(appConfig.Classifier)
+	if err != nil {
+		return err
+	}
+	if closer, ok := classifier.(io.Closer); ok {
+		defer closer.Close()
+	}
+
+	modules := make([]golicenses.ModuleHistory, 0, len(results))
+	for _, res := range results {
+		mh, err := history.Module(res, classifier, historyDownloadFlag)
+		if err != nil {
+			return fmt.Errorf("unable to read the history of %s: %w", res.Module, err)
+		}
+		modules = append(modules, mh)
+	}
+
+	if format == "json" {
+		return writeHistoryJSON(os.Stdout, modules)
+	}
+	return writeHistoryText(os.Stdout, modules, appConfig.Verbose > 0)
+}
+
+// moduleResults keeps one result per module, sorted by module. Results
+// without module information are kept by library.
+func moduleResults(results []golicenses.LicenseResult) []golicenses.LicenseResult {
+	seen := make(map[string]bool)
+	var modules []golicenses.LicenseResult
+	for _, res := range results {
+		if res.Module == "" {
+			res.Module = res.Library
+		}
+		if seen[res.Module] {
+			continue
+		}
+		seen[res.Module] = true
+		modules = append(modules, res)
+	}
+	sort.SliceStable(modules, func(i, j int) bool {
+		return modules[i].Module < modules[j].Module
+	})
+	return modules
+}
+
+func writeHistoryText(w io.Writer, modules []golicenses.ModuleHistory, verbose bool) error {
+	bw := bufio.NewWriter(w)
+	for idx, mh := range modules {
+		if idx > 0 {
+			fmt.Fprintln(bw)
+		}
+		module := mh.Module
+		if mh.Version != "" {
+			module += " " + mh.Version
+		}
+		fmt.Fprintf(bw, "%s (%s)\n", module, orNone(mh.License))
+		if mh.Added != nil {
+			fmt.Fprintf(bw, "  added:           %s\n", mh.Added)
+		} else {
+			fmt.Fprintln(bw, "  added:           not committed")
+		}
+		switch {
+		case mh.LicenseChanged != nil:
+			change := fmt.Sprintf("%s -> %s", mh.LicenseChanged.From, mh.LicenseChanged.To)
+			if mh.LicenseChanged.Version != "" {
+				change += " at " + mh.LicenseChanged.Version
+			}
+			fmt.Fprintf(bw, "  license changed: %s (%s)\n", mh.LicenseChanged.Commit, change)
+		case len(mh.Unknown) > 0:
+			fmt.Fprintf(bw, "  license changed: unknown, %s is not in the module cache (see --download)\n", mh.Unknown[0])
+		case mh.Added != nil:
+			fmt.Fprintln(bw, "  license changed: never since added")
+		}
+		if verbose {
+			for _, event := range mh.Versions {
+				fmt.Fprintf(bw, "  %-16s %s\n", orNone(event.Version)+":", event.Commit)
+			}
+		}
+	}
+	return bw.Flush()
+}
+
+type jsonHistoryCommit struct {
+	Hash    string    `json:"hash"`
+	Author  string    `json:"author"`
+	Email   string    `json:"email"`
+	Date    time.Time `json:"date"`
+	Summary string    `json:"summary"`
+}
+
+type jsonHistoryVersion struct {
+	Version string            `json:"version"`
+	Commit  jsonHistoryCommit `json:"commit"`
+}
+
+type jsonLicenseChange struct {
+	Commit  jsonHistoryCommit `json:"commit"`
+	Version string            `json:"version,omitempty"`
+	From    string            `json:"from"`
+	To      string            `json:"to"`
+}
+
+type jsonModuleHistory struct {
+	Module         string               `json:"module"`
+	Version        string               `json:"version,omitempty"`
+	License        string               `json:"license"`
+	Added          *jsonHistoryCommit   `json:"added"`
+	LicenseChanged *jsonLicenseChange   `json:"license-changed"`
+	Unknown        []string             `json:"unknown-versions,omitempty"`
+	Versions       []jsonHistoryVersion `json:"versions"`
+}
+
+func writeHistoryJSON(w io.Writer, modules []golicenses.ModuleHistory) error {
+	toJSON := func(c golicenses.HistoryCommit) jsonHistoryCommit {
+		return jsonHistoryCommit{Hash: c.Hash, Author: c.Author, Email: c.Email, Date: c.When, Summary: c.Summary}
+	}
+	doc := make([]jsonModuleHistory, 0, len(modules))
+	for _, mh := range modules {
+		entry := jsonModuleHistory{
+			Module:   mh.Module,
+			Version:  mh.Version,
+			License:  mh.License,
+			Unknown:  mh.Unknown,
+			Versions: make([]jsonHistoryVersion, 0, len(mh.Versions)),
+		}
+		if mh.Added != nil {
+			added := toJSON(*mh.Added)
+			entry.Added = &added
+		}
+		if c := mh.LicenseChanged; c != nil {
+			entry.LicenseChanged = &jsonLicenseChange{Commit: toJSON(c.Commit), Version: c.Version, From: c.From, To: c.To}
+		}
+		for _, event := range mh.Versions {
+			entry.Versions = append(entry.Versions, jsonHistoryVersion{Version: event.Version, Commit: toJSON(event.Commit)})
+		}
+		doc = append(doc, entry)
+	}
+	enc := encjson.NewEncoder(w)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	return enc.Encode(doc)
+}
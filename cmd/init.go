@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 
+	"github.com/khulnasoft/go-licenses/golicenses"
 	"github.com/khulnasoft/go-licenses/golicenses/presenter"
 	"github.com/khulnasoft/go-licenses/internal/config"
 	"github.com/spf13/viper"
@@ -25,6 +26,26 @@ func setCliOptions() {
 		os.Exit(1)
 	}
 
+	flag = "classifier"
+	rootCmd.PersistentFlags().String(
+		flag, golicenses.GoogleClassifier,
+		fmt.Sprintf("license classifier backend, options=%v", golicenses.ClassifierBackends),
+	)
+	if err := viper.BindPFlag(flag, rootCmd.PersistentFlags().Lookup(flag)); err != nil {
+		fmt.Printf("unable to bind flag '%s': %+v", flag, err)
+		os.Exit(1)
+	}
+
+	flag = "license-db"
+	rootCmd.PersistentFlags().String(
+		flag, "",
+		"license database archive to use instead of the embedded one (see 'db build')",
+	)
+	if err := viper.BindPFlag(flag, rootCmd.PersistentFlags().Lookup(flag)); err != nil {
+		fmt.Printf("unable to bind flag '%s': %+v", flag, err)
+		os.Exit(1)
+	}
+
 	flag = "verbose"
 	rootCmd.PersistentFlags().CountP(
 		flag, "v",
@@ -4,7 +4,6 @@ import (
 	"fmt"
 	"os"
 
-	"github.com/khulnasoft/go-licenses/golicenses"
 	"github.com/khulnasoft/go-licenses/golicenses/presenter"
 	"github.com/spf13/cobra"
 )
@@ -29,7 +28,7 @@ var listTemplateFileFlag string
 
 func init() {
 	listCmd.Flags().StringArrayVar(&gitRemotes, "git-remote", []string{"origin", "upstream"}, "Remote Git repositories to try")
-	listCmd.Flags().StringVar(&listFormatFlag, "format", "text", "Output format: text, csv, json, markdown, html, spdx, template")
+	listCmd.Flags().StringVar(&listFormatFlag, "format", "text", "Output format: text, csv, json, markdown, html, spdx, cyclonedx-json, cyclonedx-xml, junit, template")
 	listCmd.Flags().StringVar(&listTemplateFileFlag, "template-file", "", "Path to Go template file (used only if --format=template)")
 	rootCmd.AddCommand(listCmd)
 }
@@ -48,7 +47,7 @@ func doListCmd(cmd *cobra.Command, args []string) error {
 	} else {
 		paths = []string{"."}
 	}
-	licenseFinder := golicenses.NewLicenseFinder(paths, gitRemotes, 0.9)
+	licenseFinder := newLicenseFinder(paths)
 
 	resultStream, err := licenseFinder.Find()
 	if err != nil {
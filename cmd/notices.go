@@ -0,0 +1,114 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/khulnasoft/go-licenses/golicenses"
+	"github.com/khulnasoft/go-licenses/golicenses/presenter/attribution"
+	"github.com/spf13/cobra"
+)
+
+// noticesCmd represents the notices command
+var noticesCmd = &cobra.Command{
+	Use:   "notices [path...]",
+	Short: "Print the combined NOTICE files of all dependencies",
+	Long: `Print the combined NOTICE files of all dependencies, as required for redistributing
+Apache-2.0 licensed code. Identical notices are printed once, sorted by module.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		err := doNoticesCmd(cmd, args)
+		if err != nil {
+			fmt.Println(err.Error())
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(noticesCmd)
+}
+
+func doNoticesCmd(cmd *cobra.Command, args []string) error {
+	results, err := findResults(args)
+	if err != nil {
+		return err
+	}
+	notices, err := golicenses.CollectNotices(results...)
+	if err != nil {
+		return err
+	}
+	return writeNotices(os.Stdout, notices)
+}
+
+// writeNotices prints each notice under a header naming the modules that ship it.
+func writeNotices(w io.Writer, notices []golicenses.Notice) error {
+	for i, notice := range notices {
+		if i > 0 {
+			if _, err := fmt.Fprintln(w); err != nil {
+				return err
+			}
+		}
+		header := strings.Join(notice.Modules, ", ")
+		if _, err := fmt.Fprintf(w, "%s\n%s\n\n%s\n", header, strings.Repeat("=", 80), notice.Text); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// noticesGenerateCmd represents the notices generate command
+var noticesGenerateCmd = &cobra.Command{
+	Use:   "generate [path...]",
+	Short: "Write a third-party notices attribution document",
+	Long: `Write a third-party notices attribution document (e.g. THIRD_PARTY_NOTICES) with the
+license, copyright statements and NOTICE texts of all dependencies. License texts shared
+by several modules are included once and referenced. The output is deterministic, so the
+document can be committed.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		err := doNoticesGenerateCmd(cmd, args)
+		if err != nil {
+			fmt.Println(err.Error())
+			os.Exit(1)
+		}
+	},
+}
+
+var noticesFormatFlag string
+var noticesFileFlag string
+
+func init() {
+	noticesGenerateCmd.Flags().StringVar(&noticesFormatFlag, "format", "text", "Output format: text, markdown, html")
+	noticesGenerateCmd.Flags().StringVar(&noticesFileFlag, "file", "", "File to write the document to (default: stdout)")
+	noticesCmd.AddCommand(noticesGenerateCmd)
+}
+
+func doNoticesGenerateCmd(cmd *cobra.Command, args []string) error {
+	format, err := attribution.ParseFormat(noticesFormatFlag)
+	if err != nil {
+		return err
+	}
+	results, err := findResults(args)
+	if err != nil {
+		return err
+	}
+	doc, err := golicenses.BuildAttribution(results...)
+	if err != nil {
+		return err
+	}
+	pres := attribution.NewPresenter(doc, format)
+	if noticesFileFlag == "" {
+		return pres.Present(os.Stdout)
+	}
+
+	f, err := os.Create(noticesFileFlag)
+	if err != nil {
+		return fmt.Errorf("unable to create %s: %w", noticesFileFlag, err)
+	}
+	if err := pres.Present(f); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
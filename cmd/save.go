@@ -0,0 +1,45 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/khulnasoft/go-licenses/golicenses"
+	"github.com/spf13/cobra"
+)
+
+// saveCmd represents the save command
+var saveCmd = &cobra.Command{
+	Use:   "save [path...]",
+	Short: "Copy license, NOTICE and copyright files of all dependencies into a directory",
+	Long: `Copy the license, NOTICE and copyright files of all dependencies into a per-module
+directory under --save-path. The full module source is copied for dependencies under
+restricted or reciprocal licenses.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		err := doSaveCmd(cmd, args)
+		if err != nil {
+			fmt.Println(err.Error())
+			os.Exit(1)
+		}
+	},
+}
+
+var savePathFlag string
+var saveForceFlag bool
+
+func init() {
+	saveCmd.Flags().StringVar(&savePathFlag, "save-path", "", "Directory to save the files to (required)")
+	saveCmd.Flags().BoolVar(&saveForceFlag, "force", false, "Delete the save path first if it already exists")
+	rootCmd.AddCommand(saveCmd)
+}
+
+func doSaveCmd(cmd *cobra.Command, args []string) error {
+	if savePathFlag == "" {
+		return fmt.Errorf("--save-path must be provided")
+	}
+	results, err := findResults(args)
+	if err != nil {
+		return err
+	}
+	return golicenses.Save(savePathFlag, saveForceFlag, results...)
+}
@@ -26,8 +26,8 @@ var treeCmd = &cobra.Command{
 			importPath = args[0]
 		}
 
-		// GetLicenseDBArchiveFetcher returns ([]byte, error) and is suitable directly for licenseclassifier.ArchiveFunc.
-		dbOpt := licenseclassifier.ArchiveFunc(golicenses.GetLicenseDBArchiveFetcher)
+		// LicenseDBArchiveFetcher returns a func() ([]byte, error), suitable directly for licenseclassifier.ArchiveFunc.
+		dbOpt := licenseclassifier.ArchiveFunc(golicenses.LicenseDBArchiveFetcher(appConfig.LicenseDB))
 
 		// Use the global appConfig from cmd/init.go.
 		confidenceThreshold := appConfig.ConfidenceThreshold
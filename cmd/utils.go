@@ -10,3 +10,35 @@ func getLibrariesFromResults(results []golicenses.LicenseResult) []string {
 	}
 	return libraries
 }
+
+// findResults scans the given paths (the current directory by default) and
+// collects all license results.
+func findResults(args []string) ([]golicenses.LicenseResult, error) {
+	paths := args
+	if len(paths) == 0 {
+		paths = []string{"."}
+	}
+	licenseFinder := newLicenseFinder(paths)
+
+	resultStream, err := licenseFinder.Find()
+	if err != nil {
+		return nil, err
+	}
+	var results []golicenses.LicenseResult
+	for res := range resultStream {
+		results = append(results, res)
+	}
+	return results, nil
+}
+
+// newLicenseFinder creates a LicenseFinder for the given paths that uses the
+// configured git remotes and classifier.
+func newLicenseFinder(paths []string) golicenses.LicenseFinder {
+	licenseFinder := golicenses.NewLicenseFinder(paths, gitRemotes, 0.9)
+	licenseFinder.Classifier = appConfig.Classifier
+	licenseFinder.Plugin = appConfig.ClassifierPlugin
+	licenseFinder.CustomLicenses = appConfig.CustomLicenses
+	licenseFinder.LicenseDB = appConfig.LicenseDB
+	licenseFinder.Overrides = appConfig.Overrides
+	return licenseFinder
+}
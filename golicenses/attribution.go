@@ -0,0 +1,236 @@
+package golicenses
+
+import (
+	"fmt"
+	"html"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/khulnasoft/go-licenses/golicenses/licenses"
+)
+
+var licenseIDRegexp = regexp.MustCompile(`[^A-Za-z0-9.-]+`)
+
+// Attribution is the content of a third-party notices document: every library
+// with its copyright statements and NOTICE texts, plus the license texts they
+// reference. Identical license texts (ignoring whitespace) are only included once.
+type Attribution struct {
+	Entries  []AttributionEntry
+	Licenses []LicenseText
+}
+
+// AttributionEntry describes a single library in an Attribution.
+type AttributionEntry struct {
+	// Name is the module label of the library (see ModuleLabel).
+	Name    string
+	Library string
+	License string
+	// LicenseID references a LicenseText of the Attribution. It is empty if
+	// the library's license text is unknown.
+	LicenseID  string
+	Copyrights []string
+	Notices    []string
+	// ManuallyAsserted is set if the license was set by an Override.
+	ManuallyAsserted bool
+}
+
+// LicenseText is a license text shared by one or more libraries.
+type LicenseText struct {
+	// ID is a stable identifier derived from the license name, e.g. "MIT" or "MIT-2".
+	ID      string
+	License string
+	Text    string
+	// Entries are the names of the libraries using this text.
+	Entries []string
+}
+
+// BuildAttribution reads the license and NOTICE files of the given results
+// and assembles an Attribution. The result only depends on the results and
+// file contents, not their order, so it can be committed and compared.
+func BuildAttribution(results ...LicenseResult) (Attribution, error) {
+	sorted := append([]LicenseResult(nil), results...)
+	sort.Slice(sorted, func(i, j int) bool {
+		if a, b := ModuleLabel(sorted[i]), ModuleLabel(sorted[j]); a != b {
+			return a < b
+		}
+		return sorted[i].Library < sorted[j].Library
+	})
+
+	var doc Attribution
+	seen := make(map[string]bool)
+	textIDs := make(map[string]string)
+	idCount := make(map[string]int)
+	for _, res := range sorted {
+		entry := AttributionEntry{
+			Name:             ModuleLabel(res),
+			Library:          res.Library,
+			License:          res.License,
+			Copyrights:       res.Copyrights,
+			ManuallyAsserted: res.ManuallyAsserted,
+		}
+		// libraries of the same module usually share their license, only list it once
+		key := entry.Name + "\x00" + res.Path
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+
+		if res.Path != "" {
+			text, err := ReadLicenseText(res.Path)
+			if err != nil {
+				return Attribution{}, fmt.Errorf("unable to read license file (%s): %w", res.Path, err)
+			}
+			// texts that only differ in formatting are the same license text
+			textKey := strings.Join(strings.Fields(text), " ")
+			id, ok := textIDs[textKey]
+			if !ok {
+				id = licenseTextID(res.License, idCount)
+				textIDs[textKey] = id
+				doc.Licenses = append(doc.Licenses, LicenseText{ID: id, License: res.License, Text: text})
+			}
+			entry.LicenseID = id
+			for i := range doc.Licenses {
+				if doc.Licenses[i].ID == id {
+					doc.Licenses[i].Entries = append(doc.Licenses[i].Entries, entry.Name)
+				}
+			}
+		}
+		for _, path := range res.NoticePaths {
+			text, err := readText(path)
+			if err != nil {
+				return Attribution{}, fmt.Errorf("unable to read NOTICE file (%s): %w", path, err)
+			}
+			if text != "" {
+				entry.Notices = append(entry.Notices, text)
+			}
+		}
+		doc.Entries = append(doc.Entries, entry)
+	}
+	// keep the numbering order of texts that share a license name
+	sort.SliceStable(doc.Licenses, func(i, j int) bool {
+		return doc.Licenses[i].License < doc.Licenses[j].License
+	})
+	return doc, nil
+}
+
+// licenseTextID derives a unique, anchor-safe ID for a license text.
+func licenseTextID(license string, idCount map[string]int) string {
+	base := strings.Trim(licenseIDRegexp.ReplaceAllString(license, "-"), "-")
+	if base == "" {
+		base = "license"
+	}
+	idCount[base]++
+	if n := idCount[base]; n > 1 {
+		return fmt.Sprintf("%s-%d", base, n)
+	}
+	return base
+}
+
+// ReadLicenseText returns the license text of a license path, i.e. only the
+// relevant part of README and Go source files.
+func ReadLicenseText(path string) (string, error) {
+	text, err := readText(path)
+	if err != nil {
+		return "", err
+	}
+	switch licenses.SourceKindOf(path) {
+	case licenses.ReadmeSource:
+		if section, ok := licenses.ExtractLicenseSection(text); ok {
+			return section, nil
+		}
+	case licenses.SourceHeaderSource:
+		if header, ok := licenses.ExtractHeaderComment(text); ok {
+			return header, nil
+		}
+	}
+	return text, nil
+}
+
+// readText reads a text file, normalizing line endings and trimming
+// surrounding whitespace.
+func readText(path string) (string, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(strings.ReplaceAll(string(content), "\r\n", "\n")), nil
+}
+
+// AttributionProblemKind classifies why an attribution document is out of date.
+type AttributionProblemKind string
+
+// Attribution problem kinds
+const (
+	MissingEntry       = AttributionProblemKind("missing library")
+	StaleVersion       = AttributionProblemKind("stale version")
+	MissingLicenseText = AttributionProblemKind("missing license text")
+	MissingNotice      = AttributionProblemKind("missing NOTICE")
+)
+
+// AttributionProblem is a difference between an attribution document and the
+// current dependencies.
+type AttributionProblem struct {
+	// Entry is the name of the library entry (see ModuleLabel).
+	Entry  string
+	Kind   AttributionProblemKind
+	Detail string
+}
+
+func (p AttributionProblem) String() string {
+	if p.Detail == "" {
+		return fmt.Sprintf("%s: %s", p.Entry, p.Kind)
+	}
+	return fmt.Sprintf("%s: %s (%s)", p.Entry, p.Kind, p.Detail)
+}
+
+// containsEntry reports whether content has an entry heading for name: the
+// name must stand alone, delimited by whitespace or markup, so that e.g.
+// "example.com/mod@v1.2.10" is no entry for "example.com/mod@v1.2.1".
+func containsEntry(content, name string) bool {
+	return regexp.MustCompile(`(?:^|[\s>#])` + regexp.QuoteMeta(name) + `(?:$|[\s<])`).MatchString(content)
+}
+
+// VerifyAttribution checks that the content of a committed attribution
+// document (in any of the generated formats) covers every entry of doc with
+// its current version, license text and NOTICE texts. Whitespace and HTML
+// escaping are ignored.
+func VerifyAttribution(doc Attribution, content string) []AttributionProblem {
+	normalize := func(s string) string {
+		return strings.Join(strings.Fields(s), " ")
+	}
+	content = normalize(html.UnescapeString(content))
+
+	licenseTexts := make(map[string]LicenseText)
+	for _, l := range doc.Licenses {
+		licenseTexts[l.ID] = l
+	}
+
+	var problems []AttributionProblem
+	for _, e := range doc.Entries {
+		if !containsEntry(content, e.Name) {
+			problem := AttributionProblem{Entry: e.Name, Kind: MissingEntry}
+			if module, version, ok := strings.Cut(e.Name, "@"); ok {
+				stale := regexp.MustCompile(`(?:^|[\s>(\[#])` + regexp.QuoteMeta(module) + `@([^\s<>"()\[\],]+)`).FindStringSubmatch(content)
+				if stale != nil {
+					problem.Kind = StaleVersion
+					problem.Detail = fmt.Sprintf("found %s, current %s", stale[1], version)
+				}
+			}
+			problems = append(problems, problem)
+			if problem.Kind == MissingEntry {
+				continue
+			}
+		}
+		if l, ok := licenseTexts[e.LicenseID]; ok && !strings.Contains(content, normalize(l.Text)) {
+			problems = append(problems, AttributionProblem{Entry: e.Name, Kind: MissingLicenseText, Detail: l.License})
+		}
+		for _, n := range e.Notices {
+			if !strings.Contains(content, normalize(n)) {
+				problems = append(problems, AttributionProblem{Entry: e.Name, Kind: MissingNotice})
+			}
+		}
+	}
+	return problems
+}
@@ -0,0 +1,142 @@
+package golicenses
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-test/deep"
+)
+
+func TestBuildAttribution(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+	apacheA := write("a/LICENSE", "Apache License\r\nVersion 2.0\n")
+	apacheB := write("b/LICENSE", "Apache License\nVersion 2.0")
+	mitC := write("c/LICENSE", "MIT for C")
+	mitD := write("d/README.md", "# D\n\n## License\n\nMIT for D\n")
+	notice := write("a/NOTICE", "A notice\n")
+
+	results := []LicenseResult{
+		{Library: "example.com/d", Module: "example.com/d", Version: "v1.0.0", License: "MIT", Path: mitD},
+		{Library: "example.com/c", Module: "example.com/c", Version: "v1.0.0", License: "MIT", Path: mitC, Copyrights: []string{"Copyright 2020 C"}},
+		{Library: "example.com/b", Module: "example.com/b", Version: "v2.0.0", License: "Apache-2.0", Path: apacheB},
+		{Library: "example.com/a/pkg", Module: "example.com/a", Version: "v0.1.0", License: "Apache-2.0", Path: apacheA, NoticePaths: []string{notice}},
+		{Library: "example.com/a/other", Module: "example.com/a", Version: "v0.1.0", License: "Apache-2.0", Path: apacheA, NoticePaths: []string{notice}},
+		{Library: "example.com/e", Module: "example.com/e", Version: "v0.0.1"},
+	}
+
+	doc, err := BuildAttribution(results...)
+	if err != nil {
+		t.Fatalf("BuildAttribution() error: %v", err)
+	}
+	expected := Attribution{
+		Entries: []AttributionEntry{
+			{Name: "example.com/a@v0.1.0", Library: "example.com/a/other", License: "Apache-2.0", LicenseID: "Apache-2.0", Notices: []string{"A notice"}},
+			{Name: "example.com/b@v2.0.0", Library: "example.com/b", License: "Apache-2.0", LicenseID: "Apache-2.0"},
+			{Name: "example.com/c@v1.0.0", Library: "example.com/c", License: "MIT", LicenseID: "MIT", Copyrights: []string{"Copyright 2020 C"}},
+			{Name: "example.com/d@v1.0.0", Library: "example.com/d", License: "MIT", LicenseID: "MIT-2"},
+			{Name: "example.com/e@v0.0.1", Library: "example.com/e"},
+		},
+		Licenses: []LicenseText{
+			{ID: "Apache-2.0", License: "Apache-2.0", Text: "Apache License\nVersion 2.0", Entries: []string{"example.com/a@v0.1.0", "example.com/b@v2.0.0"}},
+			{ID: "MIT", License: "MIT", Text: "MIT for C", Entries: []string{"example.com/c@v1.0.0"}},
+			{ID: "MIT-2", License: "MIT", Text: "MIT for D", Entries: []string{"example.com/d@v1.0.0"}},
+		},
+	}
+	for _, d := range deep.Equal(expected, doc) {
+		t.Errorf("diff: %+v", d)
+	}
+}
+
+func TestVerifyAttribution(t *testing.T) {
+	doc := Attribution{
+		Entries: []AttributionEntry{
+			{Name: "example.com/a@v1.0.0", License: "MIT", LicenseID: "MIT", Notices: []string{"A & notice"}},
+			{Name: "example.com/b@v1.2.0", License: "MIT", LicenseID: "MIT"},
+			{Name: "example.com/c@v1.0.0", License: "BSD", LicenseID: "BSD"},
+			{Name: "example.com/d@v1.0.0"},
+		},
+		Licenses: []LicenseText{
+			{ID: "BSD", License: "BSD", Text: "BSD\ntext"},
+			{ID: "MIT", License: "MIT", Text: "MIT\ntext"},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		content  string
+		expected []AttributionProblem
+	}{
+		{
+			name: "up to date html",
+			content: "<h2>example.com/a@v1.0.0</h2><pre>A &amp; notice</pre><h2>example.com/b@v1.2.0</h2>" +
+				"<h2>example.com/c@v1.0.0</h2><h2>example.com/d@v1.0.0</h2><pre>MIT   text</pre><pre>BSD\n  text</pre>",
+		},
+		{
+			name:    "stale and missing",
+			content: "example.com/a@v1.0.0\n\nMIT text\n\n## example.com/b@v1.1.0\n\nexample.com/c@v1.0.0\n",
+			expected: []AttributionProblem{
+				{Entry: "example.com/a@v1.0.0", Kind: MissingNotice},
+				{Entry: "example.com/b@v1.2.0", Kind: StaleVersion, Detail: "found v1.1.0, current v1.2.0"},
+				{Entry: "example.com/c@v1.0.0", Kind: MissingLicenseText, Detail: "BSD"},
+				{Entry: "example.com/d@v1.0.0", Kind: MissingEntry},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			problems := VerifyAttribution(doc, test.content)
+			for _, d := range deep.Equal(test.expected, problems) {
+				t.Errorf("diff: %+v", d)
+			}
+		})
+	}
+
+	// names that only share a prefix with an entry do not attribute it
+	prefixDoc := Attribution{Entries: []AttributionEntry{
+		{Name: "example.com/mod@v1.2.1"},
+		{Name: "foo/b"},
+	}}
+	for _, test := range []struct {
+		name     string
+		content  string
+		expected []AttributionProblem
+	}{
+		{
+			name:    "version prefix",
+			content: "## example.com/mod@v1.2.10\n\n## foo/b\n",
+			expected: []AttributionProblem{
+				{Entry: "example.com/mod@v1.2.1", Kind: StaleVersion, Detail: "found v1.2.10, current v1.2.1"},
+			},
+		},
+		{
+			name:    "module prefix",
+			content: "<h2>example.com/mod@v1.2.1</h2><h2>foo/bar</h2>",
+			expected: []AttributionProblem{
+				{Entry: "foo/b", Kind: MissingEntry},
+			},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			problems := VerifyAttribution(prefixDoc, test.content)
+			for _, d := range deep.Equal(test.expected, problems) {
+				t.Errorf("diff: %+v", d)
+			}
+		})
+	}
+
+	p := AttributionProblem{Entry: "example.com/b@v1.2.0", Kind: StaleVersion, Detail: "found v1.1.0, current v1.2.0"}
+	if got, want := p.String(), "example.com/b@v1.2.0: stale version (found v1.1.0, current v1.2.0)"; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+}
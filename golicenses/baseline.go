@@ -0,0 +1,116 @@
+package golicenses
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+)
+
+// DefaultBaselineFile is the baseline file used when none is given.
+const DefaultBaselineFile = ".golicenses-baseline.json"
+
+// Baseline records accepted violations, so that only new ones are reported.
+type Baseline struct {
+	Violations []BaselineEntry `json:"violations"`
+}
+
+// BaselineEntry identifies an accepted violation.
+type BaselineEntry struct {
+	Module  string `json:"module"`
+	Version string `json:"version,omitempty"`
+	License string `json:"license"`
+}
+
+func (e BaselineEntry) String() string {
+	module := e.Module
+	if e.Version != "" {
+		module += "@" + e.Version
+	}
+	license := e.License
+	if license == "" {
+		license = "unknown license"
+	}
+	return fmt.Sprintf("%s (%s)", module, license)
+}
+
+// NewBaseline records the violations of warn or error severity. Libraries of
+// the same module share an entry.
+func NewBaseline(violations []Violation) Baseline {
+	seen := make(map[BaselineEntry]bool)
+	entries := make([]BaselineEntry, 0)
+	for _, v := range violations {
+		if v.Severity < WarnSeverity {
+			continue
+		}
+		e := baselineEntry(v.Result)
+		if !seen[e] {
+			seen[e] = true
+			entries = append(entries, e)
+		}
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].Module != entries[j].Module {
+			return entries[i].Module < entries[j].Module
+		}
+		if entries[i].Version != entries[j].Version {
+			return entries[i].Version < entries[j].Version
+		}
+		return entries[i].License < entries[j].License
+	})
+	return Baseline{Violations: entries}
+}
+
+func baselineEntry(res LicenseResult) BaselineEntry {
+	module := res.Module
+	if module == "" {
+		module = res.Library
+	}
+	return BaselineEntry{Module: module, Version: res.Version, License: res.License}
+}
+
+// ReadBaseline reads a baseline file written by Baseline.Write.
+func ReadBaseline(path string) (Baseline, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return Baseline{}, fmt.Errorf("unable to read baseline: %w", err)
+	}
+	var b Baseline
+	if err := json.Unmarshal(content, &b); err != nil {
+		return Baseline{}, fmt.Errorf("unable to parse baseline %s: %w", path, err)
+	}
+	return b, nil
+}
+
+// Write writes the baseline as JSON.
+func (b Baseline) Write(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(b)
+}
+
+// Filter returns the violations that are not in the baseline, and the
+// baseline entries that none of the violations match anymore.
+func (b Baseline) Filter(violations []Violation) ([]Violation, []BaselineEntry) {
+	accepted := make(map[BaselineEntry]bool)
+	for _, e := range b.Violations {
+		accepted[e] = false
+	}
+	remaining := make([]Violation, 0, len(violations))
+	for _, v := range violations {
+		e := baselineEntry(v.Result)
+		if _, ok := accepted[e]; ok {
+			accepted[e] = true
+			continue
+		}
+		remaining = append(remaining, v)
+	}
+	var removable []BaselineEntry
+	for _, e := range b.Violations {
+		if !accepted[e] {
+			removable = append(removable, e)
+		}
+	}
+	return remaining, removable
+}
@@ -0,0 +1,69 @@
+package golicenses
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-test/deep"
+)
+
+func TestBaseline(t *testing.T) {
+	gpl := func(library, module, version string) Violation {
+		return Violation{
+			Result:   LicenseResult{Library: library, Module: module, Version: version, License: "GPL-3.0"},
+			Action:   DenyAction,
+			Severity: ErrorSeverity,
+		}
+	}
+	old := []Violation{
+		gpl("github.com/foo/bar/a", "github.com/foo/bar", "v1.0.0"),
+		gpl("github.com/foo/bar/b", "github.com/foo/bar", "v1.0.0"),
+		gpl("github.com/gone/lib", "github.com/gone/lib", "v0.1.0"),
+		{Result: LicenseResult{Library: "github.com/info/lib", License: "MIT"}, Action: AllowAction, Severity: InfoSeverity},
+	}
+	baseline := NewBaseline(old)
+	expected := []BaselineEntry{
+		{Module: "github.com/foo/bar", Version: "v1.0.0", License: "GPL-3.0"},
+		{Module: "github.com/gone/lib", Version: "v0.1.0", License: "GPL-3.0"},
+	}
+	for _, d := range deep.Equal(expected, baseline.Violations) {
+		t.Errorf("diff: %+v", d)
+	}
+
+	var buf bytes.Buffer
+	if err := baseline.Write(&buf); err != nil {
+		t.Fatalf("Write() error: %v", err)
+	}
+	path := filepath.Join(t.TempDir(), DefaultBaselineFile)
+	if err := os.WriteFile(path, buf.Bytes(), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	read, err := ReadBaseline(path)
+	if err != nil {
+		t.Fatalf("ReadBaseline() error: %v", err)
+	}
+
+	current := []Violation{
+		gpl("github.com/foo/bar/a", "github.com/foo/bar", "v1.0.0"),
+		gpl("github.com/foo/bar/b", "github.com/foo/bar", "v1.0.0"),
+		gpl("github.com/new/lib", "github.com/new/lib", "v1.0.0"),
+	}
+	remaining, removable := read.Filter(current)
+	for _, d := range deep.Equal(current[2:], remaining) {
+		t.Errorf("diff: %+v", d)
+	}
+	for _, d := range deep.Equal(expected[1:], removable) {
+		t.Errorf("diff: %+v", d)
+	}
+	if s := removable[0].String(); s != "github.com/gone/lib@v0.1.0 (GPL-3.0)" {
+		t.Errorf("String() = %q", s)
+	}
+
+	// an upgraded module is a new violation
+	remaining, _ = read.Filter([]Violation{gpl("github.com/foo/bar/a", "github.com/foo/bar", "v1.1.0")})
+	if len(remaining) != 1 {
+		t.Errorf("expected the upgraded module to be reported, got %+v", remaining)
+	}
+}
@@ -0,0 +1,139 @@
+package golicenses
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/google/licenseclassifier"
+	"github.com/khulnasoft/go-licenses/golicenses/licenses"
+)
+
+// Classifier backends
+const (
+	// GoogleClassifier uses github.com/google/licenseclassifier with the license database.
+	GoogleClassifier = "google"
+	// LicensecheckClassifier uses github.com/google/licensecheck and its built-in license set.
+	LicensecheckClassifier = "licensecheck"
+	// PluginClassifier runs an external executable, see licenses.PluginConfig.
+	PluginClassifier = "plugin"
+)
+
+// ClassifierBackends lists the names of all classifier backends. The first one is the default.
+var ClassifierBackends = []string{GoogleClassifier, LicensecheckClassifier, PluginClassifier}
+
+// NewClassifier creates the classifier backend with the given name (see
+// ClassifierBackends). An empty name selects the default backend.
+// Classifiers that implement io.Closer must be closed after use.
+func (r LicenseFinder) NewClassifier(backend string) (licenses.Classifier, error) {
+	custom, err := r.CustomLicenses.Load()
+	if err != nil {
+		return nil, err
+	}
+	switch backend {
+	case "", GoogleClassifier:
+		archive := LicenseDBArchiveFetcher(r.LicenseDB)
+		if len(custom) > 0 {
+			return licenses.NewCustomClassifier(r.ConfidenceThreshold, archive, custom)
+		}
+		return licenses.NewClassifier(r.ConfidenceThreshold, licenseclassifier.ArchiveFunc(archive))
+	case LicensecheckClassifier:
+		return licenses.NewLicensecheckClassifier(r.ConfidenceThreshold, custom...)
+	case PluginClassifier:
+		return licenses.NewPluginClassifier(r.Plugin, r.ConfidenceThreshold)
+	default:
+		return nil, fmt.Errorf("unknown classifier %q (supported: %s)", backend, strings.Join(ClassifierBackends, ", "))
+	}
+}
+
+// closeClassifier stops classifiers that hold resources, like plugin processes.
+func closeClassifier(c licenses.Classifier) {
+	if closer, ok := c.(io.Closer); ok {
+		_ = closer.Close()
+	}
+}
+
+// Classification is the result of identifying a license file with one classifier backend.
+type Classification struct {
+	Classifier string
+	License    string
+	Type       string
+	Errs       error
+}
+
+// ClassifierDisagreement is a library whose license file was identified
+// differently by the compared classifier backends.
+type ClassifierDisagreement struct {
+	Library string
+	Path    string
+	// Classifications are ordered like the compared backends.
+	Classifications []Classification
+}
+
+// CompareClassifiers identifies the license of every library found in paths
+// with each of the given classifier backends, and returns the libraries where
+// the backends disagree on the license name or type, sorted by library.
+// License files are located with the first backend, so all backends classify
+// the same files.
+func (r LicenseFinder) CompareClassifiers(backends ...string) ([]ClassifierDisagreement, error) {
+	if len(backends) < 2 {
+		return nil, fmt.Errorf("at least two classifiers are required for a comparison")
+	}
+	// suppress log events from go-licenses
+	flag.Parse()
+	_ = flag.Lookup("logtostderr").Value.Set("false")
+
+	classifiers := make([]licenses.Classifier, 0, len(backends))
+	defer func() {
+		for _, c := range classifiers {
+			closeClassifier(c)
+		}
+	}()
+	for _, backend := range backends {
+		c, err := r.NewClassifier(backend)
+		if err != nil {
+			return nil, err
+		}
+		classifiers = append(classifiers, c)
+	}
+
+	libs, err := licenses.Libraries(context.Background(), classifiers[0], r.Paths...)
+	if err != nil {
+		return nil, err
+	}
+
+	var disagreements []ClassifierDisagreement
+	for _, lib := range libs {
+		if lib.LicensePath == "" {
+			continue
+		}
+		d := ClassifierDisagreement{Library: unvendor(lib.Name()), Path: lib.LicensePath}
+		agree := true
+		for i, c := range classifiers {
+			name, classification, err := c.Identify(lib.LicensePath)
+			if err != nil {
+				name = ""
+			}
+			d.Classifications = append(d.Classifications, Classification{
+				Classifier: backends[i],
+				License:    name,
+				Type:       classification.String(),
+				Errs:       err,
+			})
+			first := d.Classifications[0]
+			if name != first.License || classification.String() != first.Type {
+				agree = false
+			}
+		}
+		if !agree {
+			disagreements = append(disagreements, d)
+		}
+	}
+	sort.Slice(disagreements, func(i, j int) bool {
+		return disagreements[i].Library < disagreements[j].Library
+	})
+	return disagreements, nil
+}
@@ -0,0 +1,38 @@
+package golicenses
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestNewClassifier(t *testing.T) {
+	finder := NewLicenseFinder(nil, nil, 0.9)
+	for _, backend := range []string{"", GoogleClassifier, LicensecheckClassifier} {
+		if _, err := finder.NewClassifier(backend); err != nil {
+			t.Errorf("NewClassifier(%q) error: %v", backend, err)
+		}
+	}
+	if _, err := finder.NewClassifier(PluginClassifier); err == nil {
+		t.Error("expected error for the plugin classifier without a command")
+	}
+	_, err := finder.NewClassifier("bogus")
+	if err == nil || !strings.Contains(err.Error(), "unknown classifier") {
+		t.Errorf("expected unknown classifier error, got %v", err)
+	}
+}
+
+func TestCompareClassifiers(t *testing.T) {
+	finder := NewLicenseFinder([]string{"./testdata/multi"}, []string{"origin"}, 0.9)
+	if _, err := finder.CompareClassifiers(GoogleClassifier); err == nil {
+		t.Error("expected error when comparing a single classifier")
+	}
+	if _, err := finder.CompareClassifiers(GoogleClassifier, "bogus"); err == nil {
+		t.Error("expected error for an unknown classifier")
+	}
+}
+
+func TestLicenseDBArchiveFetcher(t *testing.T) {
+	if _, err := LicenseDBArchiveFetcher("does-not-exist.db")(); err == nil || !strings.Contains(err.Error(), "license database") {
+		t.Errorf("expected error reading a missing license database, got %v", err)
+	}
+}
@@ -0,0 +1,104 @@
+package golicenses
+
+import (
+	"sort"
+	"strings"
+)
+
+// ReportModule is a module of a license report: the packages of a module
+// are merged, and the distinct licenses and types of the packages joined.
+type ReportModule struct {
+	Module  string
+	Version string
+	License string
+	Type    string
+}
+
+// ModuleChange is a module present in both reports whose version, license or type changed.
+type ModuleChange struct {
+	Old ReportModule
+	New ReportModule
+}
+
+func (c ModuleChange) VersionChanged() bool { return c.Old.Version != c.New.Version }
+func (c ModuleChange) LicenseChanged() bool { return c.Old.License != c.New.License }
+func (c ModuleChange) TypeChanged() bool    { return c.Old.Type != c.New.Type }
+
+// ReportDiff lists the differences between two license reports, sorted by module.
+type ReportDiff struct {
+	Added   []ReportModule
+	Removed []ReportModule
+	Changed []ModuleChange
+}
+
+// Empty reports whether the reports have the same modules, versions and licenses.
+func (d ReportDiff) Empty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Changed) == 0
+}
+
+// DiffReports compares the modules of two license reports. Results without
+// module information, like those of CSV reports, are compared by library name.
+func DiffReports(old, new []LicenseResult) ReportDiff {
+	oldModules, newModules := reportModules(old), reportModules(new)
+	var diff ReportDiff
+	for _, m := range sortedModules(newModules) {
+		prev, ok := oldModules[m.Module]
+		switch {
+		case !ok:
+			diff.Added = append(diff.Added, m)
+		case prev != m:
+			diff.Changed = append(diff.Changed, ModuleChange{Old: prev, New: m})
+		}
+	}
+	for _, m := range sortedModules(oldModules) {
+		if _, ok := newModules[m.Module]; !ok {
+			diff.Removed = append(diff.Removed, m)
+		}
+	}
+	return diff
+}
+
+func reportModules(results []LicenseResult) map[string]ReportModule {
+	licenses := make(map[string]map[string]bool)
+	types := make(map[string]map[string]bool)
+	modules := make(map[string]ReportModule)
+	for _, res := range results {
+		module := res.Module
+		if module == "" {
+			module = res.Library
+		}
+		if _, ok := modules[module]; !ok {
+			modules[module] = ReportModule{Module: module, Version: res.Version}
+			licenses[module] = make(map[string]bool)
+			types[module] = make(map[string]bool)
+		}
+		licenses[module][res.License] = true
+		types[module][res.Type] = true
+	}
+	for module, m := range modules {
+		m.License = joinSet(licenses[module])
+		m.Type = joinSet(types[module])
+		modules[module] = m
+	}
+	return modules
+}
+
+func joinSet(set map[string]bool) string {
+	values := make([]string, 0, len(set))
+	for v := range set {
+		if v != "" {
+			values = append(values, v)
+		}
+	}
+	sort.Strings(values)
+	return strings.Join(values, ", ")
+}
+
+func sortedModules(modules map[string]ReportModule) []ReportModule {
+	sorted := make([]ReportModule, 0, len(modules))
+	for _, m := range modules {
+		sorted = append(sorted, m)
+	}
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Module < sorted[j].Module })
+	return sorted
+}
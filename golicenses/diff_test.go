@@ -0,0 +1,64 @@
+package golicenses
+
+import (
+	"testing"
+
+	"github.com/go-test/deep"
+)
+
+func TestDiffReports(t *testing.T) {
+	old := []LicenseResult{
+		{Library: "github.com/foo/bar/a", Module: "github.com/foo/bar", Version: "v1.0.0", License: "MIT", Type: "notice"},
+		{Library: "github.com/foo/bar/b", Module: "github.com/foo/bar", Version: "v1.0.0", License: "MIT", Type: "notice"},
+		{Library: "github.com/same/lib", Module: "github.com/same/lib", Version: "v0.1.0", License: "BSD-3-Clause", Type: "notice"},
+		{Library: "github.com/gone/lib", Module: "github.com/gone/lib", Version: "v0.2.0", License: "ISC", Type: "notice"},
+		{Library: "github.com/relicensed/lib", Module: "github.com/relicensed/lib", Version: "v2.0.0", License: "Apache-2.0", Type: "notice"},
+	}
+	new := []LicenseResult{
+		{Library: "github.com/foo/bar/a", Module: "github.com/foo/bar", Version: "v1.1.0", License: "MIT", Type: "notice"},
+		{Library: "github.com/foo/bar/c", Module: "github.com/foo/bar", Version: "v1.1.0", License: "MIT", Type: "notice"},
+		{Library: "github.com/same/lib", Module: "github.com/same/lib", Version: "v0.1.0", License: "BSD-3-Clause", Type: "notice"},
+		{Library: "github.com/relicensed/lib", Module: "github.com/relicensed/lib", Version: "v2.1.0", License: "AGPL-3.0", Type: "restricted"},
+		{Library: "github.com/new/lib", License: "MIT", Type: "notice"},
+	}
+
+	diff := DiffReports(old, new)
+	expected := ReportDiff{
+		Added: []ReportModule{
+			{Module: "github.com/new/lib", License: "MIT", Type: "notice"},
+		},
+		Removed: []ReportModule{
+			{Module: "github.com/gone/lib", Version: "v0.2.0", License: "ISC", Type: "notice"},
+		},
+		Changed: []ModuleChange{
+			{
+				Old: ReportModule{Module: "github.com/foo/bar", Version: "v1.0.0", License: "MIT", Type: "notice"},
+				New: ReportModule{Module: "github.com/foo/bar", Version: "v1.1.0", License: "MIT", Type: "notice"},
+			},
+			{
+				Old: ReportModule{Module: "github.com/relicensed/lib", Version: "v2.0.0", License: "Apache-2.0", Type: "notice"},
+				New: ReportModule{Module: "github.com/relicensed/lib", Version: "v2.1.0", License: "AGPL-3.0", Type: "restricted"},
+			},
+		},
+	}
+	for _, d := range deep.Equal(expected, diff) {
+		t.Errorf("diff: %+v", d)
+	}
+	if c := diff.Changed[0]; !c.VersionChanged() || c.LicenseChanged() || c.TypeChanged() {
+		t.Errorf("unexpected changes for %s", c.New.Module)
+	}
+	if !DiffReports(old, old).Empty() {
+		t.Error("expected no differences between the same reports")
+	}
+}
+
+func TestDiffReports_MixedLicenses(t *testing.T) {
+	report := []LicenseResult{
+		{Library: "github.com/foo/bar/a", Module: "github.com/foo/bar", License: "MIT", Type: "notice"},
+		{Library: "github.com/foo/bar/b", Module: "github.com/foo/bar", License: "BSD-3-Clause", Type: "notice"},
+	}
+	modules := reportModules(report)
+	if m := modules["github.com/foo/bar"]; m.License != "BSD-3-Clause, MIT" || m.Type != "notice" {
+		t.Errorf("reportModules() = %+v", m)
+	}
+}
@@ -0,0 +1,96 @@
+package golicenses
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"time"
+)
+
+// exceptionDateLayout is the format of Exception.Expires.
+const exceptionDateLayout = "2006-01-02"
+
+// Exception accepts rule violations of a module under a specific license
+// until an expiry date, e.g. while a replacement is being worked on.
+type Exception struct {
+	// Module is a regular expression matched against the whole module path.
+	Module string `mapstructure:"module"`
+	// License is a regular expression matched against the whole license name.
+	License string `mapstructure:"license"`
+	// Owner is who is responsible for resolving the exception.
+	Owner  string `mapstructure:"owner"`
+	Reason string `mapstructure:"reason"`
+	// Expires is the last day (YYYY-MM-DD, UTC) the exception applies.
+	Expires string `mapstructure:"expires"`
+
+	modulePattern  *regexp.Regexp
+	licensePattern *regexp.Regexp
+	expiresAt      time.Time
+}
+
+// NewExceptions validates exceptions and prepares them for matching.
+func NewExceptions(exceptions ...Exception) ([]Exception, error) {
+	compiled := make([]Exception, len(exceptions))
+	for idx, e := range exceptions {
+		if e.Module == "" || e.License == "" || e.Expires == "" {
+			return nil, fmt.Errorf("exception %d: module, license and expires are required", idx+1)
+		}
+		if e.Owner == "" || e.Reason == "" {
+			return nil, fmt.Errorf("exception (%s): an owner and a reason are required", e.Module)
+		}
+		var err error
+		if e.modulePattern, err = regexp.Compile("^(?:" + e.Module + ")$"); err != nil {
+			return nil, fmt.Errorf("bad exception module pattern (%s): %w", e.Module, err)
+		}
+		if e.licensePattern, err = regexp.Compile("^(?:" + e.License + ")$"); err != nil {
+			return nil, fmt.Errorf("bad exception license pattern (%s): %w", e.License, err)
+		}
+		day, err := time.Parse(exceptionDateLayout, e.Expires)
+		if err != nil {
+			return nil, fmt.Errorf("exception (%s): bad expires date %q, expected YYYY-MM-DD", e.Module, e.Expires)
+		}
+		// the exception is valid through the whole expiry day
+		e.expiresAt = day.AddDate(0, 0, 1)
+		compiled[idx] = e
+	}
+	return compiled, nil
+}
+
+// Matches reports whether the exception covers a result, regardless of expiry.
+// Results without module information are matched by library name.
+func (e Exception) Matches(res LicenseResult) bool {
+	module := res.Module
+	if module == "" {
+		module = res.Library
+	}
+	return e.modulePattern.MatchString(module) && e.licensePattern.MatchString(res.License)
+}
+
+// Expired reports whether the exception no longer applies at the given time.
+func (e Exception) Expired(now time.Time) bool {
+	return !now.Before(e.expiresAt)
+}
+
+// ExpiresWithin reports whether an active exception expires within the given window.
+func (e Exception) ExpiresWithin(now time.Time, window time.Duration) bool {
+	return !e.Expired(now) && e.expiresAt.Sub(now) <= window
+}
+
+func (e Exception) String() string {
+	return fmt.Sprintf("%s (%s), owner %s, expires %s: %s", e.Module, e.License, e.Owner, e.Expires, e.Reason)
+}
+
+// ExpiringExceptions returns the exceptions (see NewExceptions) that are
+// expired or expire within the window, sorted by expiry date.
+func ExpiringExceptions(exceptions []Exception, now time.Time, window time.Duration) []Exception {
+	var expiring []Exception
+	for _, e := range exceptions {
+		if e.Expired(now) || e.ExpiresWithin(now, window) {
+			expiring = append(expiring, e)
+		}
+	}
+	sort.SliceStable(expiring, func(i, j int) bool {
+		return expiring[i].expiresAt.Before(expiring[j].expiresAt)
+	})
+	return expiring
+}
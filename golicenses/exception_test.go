@@ -0,0 +1,114 @@
+package golicenses
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-test/deep"
+)
+
+func TestNewExceptions(t *testing.T) {
+	valid := Exception{Module: "github.com/foo/bar", License: "GPL-3.0", Owner: "legal", Reason: "replacement planned", Expires: "2026-12-31"}
+	tests := []struct {
+		name    string
+		modify  func(e *Exception)
+		wantErr string
+	}{
+		{name: "valid", modify: func(e *Exception) {}},
+		{name: "no expiry", modify: func(e *Exception) { e.Expires = "" }, wantErr: "required"},
+		{name: "no owner", modify: func(e *Exception) { e.Owner = "" }, wantErr: "owner"},
+		{name: "bad date", modify: func(e *Exception) { e.Expires = "31.12.2026" }, wantErr: "YYYY-MM-DD"},
+		{name: "bad license pattern", modify: func(e *Exception) { e.License = "GPL(" }, wantErr: "bad exception license pattern"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			e := valid
+			test.modify(&e)
+			_, err := NewExceptions(e)
+			if test.wantErr == "" {
+				if err != nil {
+					t.Errorf("NewExceptions() error: %v", err)
+				}
+			} else if err == nil || !strings.Contains(err.Error(), test.wantErr) {
+				t.Errorf("NewExceptions() error = %v, want %q", err, test.wantErr)
+			}
+		})
+	}
+}
+
+func TestRules_EvaluateExceptions(t *testing.T) {
+	rules, err := NewRules(DenyAction, []string{"GPL.*"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	rules.Exceptions, err = NewExceptions(
+		Exception{Module: "github.com/foo/.*", License: "GPL-3.0", Owner: "legal", Reason: "replacement planned", Expires: "2026-06-30"},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	results := []LicenseResult{
+		{Library: "github.com/foo/bar/pkg", Module: "github.com/foo/bar", License: "GPL-3.0"},
+		{Library: "github.com/foo/baz", Module: "github.com/foo/baz", License: "GPL-2.0"},
+		{Library: "github.com/other/lib", Module: "github.com/other/lib", License: "GPL-3.0"},
+	}
+
+	tests := []struct {
+		name     string
+		now      time.Time
+		expected []LicenseResult
+	}{
+		{
+			name:     "before expiry",
+			now:      time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC),
+			expected: results[1:],
+		},
+		{
+			name:     "on the expiry day",
+			now:      time.Date(2026, 6, 30, 23, 0, 0, 0, time.UTC),
+			expected: results[1:],
+		},
+		{
+			name:     "after expiry",
+			now:      time.Date(2026, 7, 1, 0, 0, 0, 0, time.UTC),
+			expected: results,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			allowed, violations, err := rules.EvaluateAt(test.now, results...)
+			if err != nil {
+				t.Fatalf("EvaluateAt() error: %v", err)
+			}
+			if allowed {
+				t.Error("expected violations")
+			}
+			for _, d := range deep.Equal(test.expected, violations) {
+				t.Errorf("diff: %+v", d)
+			}
+		})
+	}
+}
+
+func TestExpiringExceptions(t *testing.T) {
+	exceptions, err := NewExceptions(
+		Exception{Module: "a", License: "GPL-3.0", Owner: "legal", Reason: "r", Expires: "2026-03-01"},
+		Exception{Module: "b", License: "GPL-3.0", Owner: "legal", Reason: "r", Expires: "2026-01-15"},
+		Exception{Module: "c", License: "GPL-3.0", Owner: "legal", Reason: "r", Expires: "2025-12-31"},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	var modules []string
+	for _, e := range ExpiringExceptions(exceptions, now, 30*24*time.Hour) {
+		modules = append(modules, e.Module)
+	}
+	for _, d := range deep.Equal([]string{"c", "b"}, modules) {
+		t.Errorf("diff: %+v", d)
+	}
+	if !exceptions[2].Expired(now) || exceptions[1].Expired(now) {
+		t.Error("expected only the exception that ended on 2025-12-31 to be expired")
+	}
+}
@@ -5,9 +5,9 @@ import (
 	"flag"
 	"fmt"
 	"io"
+	"os"
 	"strings"
 
-	"github.com/google/licenseclassifier"
 	"github.com/markbates/pkger"
 
 	"github.com/hashicorp/go-multierror"
@@ -19,6 +19,15 @@ type LicenseFinder struct {
 	Paths               []string // Directories or files to scan
 	ConfidenceThreshold float64  // Threshold for license classifier
 	GitRemotes          []string // Git remotes to use for URL resolution
+	Classifier          string   // Classifier backend, see ClassifierBackends (default if empty)
+	// Plugin configures the executable of the plugin classifier backend.
+	Plugin licenses.PluginConfig
+	// CustomLicenses are identified in addition to the licenses known by the classifier.
+	CustomLicenses licenses.CustomLicensesConfig
+	// LicenseDB is the path of a license database archive to use instead of the embedded one.
+	LicenseDB string
+	// Overrides manually assert the license of matching modules.
+	Overrides []Override
 }
 
 // NewLicenseFinder creates a new LicenseFinder instance.
@@ -42,6 +51,21 @@ func GetLicenseDBArchiveFetcher() ([]byte, error) {
 	return io.ReadAll(f)
 }
 
+// LicenseDBArchiveFetcher returns a function that reads the license database
+// archive at path, or the embedded archive if path is empty.
+func LicenseDBArchiveFetcher(path string) func() ([]byte, error) {
+	if path == "" {
+		return GetLicenseDBArchiveFetcher
+	}
+	return func() ([]byte, error) {
+		archive, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read license database: %w", err)
+		}
+		return archive, nil
+	}
+}
+
 // Find scans the provided paths and streams discovered LicenseResult objects.
 // Returns a channel of results and any error encountered during setup.
 func (r LicenseFinder) Find() (<-chan LicenseResult, error) {
@@ -49,14 +73,19 @@ func (r LicenseFinder) Find() (<-chan LicenseResult, error) {
 	flag.Parse()
 	_ = flag.Lookup("logtostderr").Value.Set("false")
 
-	dbFetcherOpt := licenseclassifier.ArchiveFunc(GetLicenseDBArchiveFetcher)
-	classifier, err := licenses.NewClassifier(r.ConfidenceThreshold, dbFetcherOpt)
+	overrides, err := NewOverrides(r.Overrides...)
+	if err != nil {
+		return nil, err
+	}
+
+	classifier, err := r.NewClassifier(r.Classifier)
 	if err != nil {
 		return nil, err
 	}
 
 	libs, err := licenses.Libraries(context.Background(), classifier, r.Paths...)
 	if err != nil {
+		closeClassifier(classifier)
 		return nil, err
 	}
 
@@ -64,6 +93,7 @@ func (r LicenseFinder) Find() (<-chan LicenseResult, error) {
 
 	go func() {
 		defer close(results)
+		defer closeClassifier(classifier)
 		for _, lib := range libs {
 			var licenseURL, licenseName string
 			var classification licenses.Type
@@ -83,14 +113,30 @@ func (r LicenseFinder) Find() (<-chan LicenseResult, error) {
 				}
 			}
 
-			results <- LicenseResult{
-				Library: unvendor(lib.Name()),
-				URL:     licenseURL,
-				Path:    lib.LicensePath,
-				License: licenseName,
-				Type:    classification.String(),
-				Errs:    errs,
+			if len(lib.SPDXIdentifiers) > 1 {
+				errs = multierror.Append(errs, fmt.Errorf("conflicting SPDX-License-Identifier tags: %s", strings.Join(lib.SPDXIdentifiers, ", ")))
 			}
+
+			var modulePath, moduleVersion, moduleDir string
+			if lib.Module != nil {
+				modulePath, moduleVersion, moduleDir = lib.Module.Path, lib.Module.Version, lib.Module.Dir
+			}
+
+			results <- ApplyOverrides(overrides, LicenseResult{
+				Library:     unvendor(lib.Name()),
+				Module:      modulePath,
+				Version:     moduleVersion,
+				Dir:         moduleDir,
+				URL:         licenseURL,
+				Path:        lib.LicensePath,
+				License:     licenseName,
+				Type:        classification.String(),
+				Source:      string(licenses.SourceKindOf(lib.LicensePath)),
+				Copyrights:  lib.Copyrights,
+				NoticePaths: lib.NoticePaths,
+				DependsOn:   lib.DependsOn,
+				Errs:        errs,
+			})
 		}
 	}()
 
@@ -0,0 +1,59 @@
+package golicenses
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Severity is how serious a rule violation is.
+type Severity int
+
+const (
+	NoSeverity Severity = iota
+	InfoSeverity
+	WarnSeverity
+	ErrorSeverity
+)
+
+var severityStr = []string{
+	"none",
+	"info",
+	"warn",
+	"error",
+}
+
+// ParseSeverity parses a severity name: info, warn (warning) or error.
+func ParseSeverity(s string) (Severity, error) {
+	switch strings.ToLower(s) {
+	case "info":
+		return InfoSeverity, nil
+	case "warn", "warning":
+		return WarnSeverity, nil
+	case "error":
+		return ErrorSeverity, nil
+	default:
+		return NoSeverity, fmt.Errorf("bad severity %q (options=info, warn, error)", s)
+	}
+}
+
+func (s Severity) String() string {
+	if int(s) >= len(severityStr) || s < 0 {
+		return severityStr[0]
+	}
+	return severityStr[s]
+}
+
+// Finding is a rule violation reported with a result, see AnnotateResults.
+type Finding struct {
+	Severity Severity
+	Action   Action
+	// Rule describes the rule that matched, including its kind and scope.
+	Rule string
+	// RuleID identifies the rule that matched (see Rule.ID).
+	RuleID  string
+	Message string
+}
+
+func (f Finding) String() string {
+	return fmt.Sprintf("%s: %s", f.Severity, f.Message)
+}
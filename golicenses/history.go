@@ -0,0 +1,426 @@
+package golicenses
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"go/build"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/khulnasoft/go-licenses/golicenses/licenses"
+	"golang.org/x/mod/module"
+	git "gopkg.in/src-d/go-git.v4"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+)
+
+// HistoryCommit is a commit of the repository history.
+type HistoryCommit struct {
+	Hash   string
+	Author string
+	Email  string
+	When   time.Time
+	// Summary is the first line of the commit message.
+	Summary string
+}
+
+func newHistoryCommit(c *object.Commit) HistoryCommit {
+	summary := strings.TrimSpace(c.Message)
+	if idx := strings.IndexByte(summary, '\n'); idx >= 0 {
+		summary = strings.TrimSpace(summary[:idx])
+	}
+	return HistoryCommit{
+		Hash:    c.Hash.String(),
+		Author:  c.Author.Name,
+		Email:   c.Author.Email,
+		When:    c.Author.When,
+		Summary: summary,
+	}
+}
+
+// ShortHash returns the abbreviated commit hash.
+func (c HistoryCommit) ShortHash() string {
+	if len(c.Hash) > 7 {
+		return c.Hash[:7]
+	}
+	return c.Hash
+}
+
+func (c HistoryCommit) String() string {
+	return fmt.Sprintf("%s %s %s <%s>: %s", c.ShortHash(), c.When.Format("2006-01-02"), c.Author, c.Email, c.Summary)
+}
+
+// VersionEvent is a commit that changed the required version of a module.
+// Version is empty if the commit removed the module.
+type VersionEvent struct {
+	Commit  HistoryCommit
+	Version string
+}
+
+// LicenseChange is a commit that changed the license of a module, either
+// by requiring a version with another license or by changing a license file
+// in the repository.
+type LicenseChange struct {
+	Commit  HistoryCommit
+	Version string
+	From    string
+	To      string
+}
+
+// ModuleHistory is the history of a module in the repository.
+type ModuleHistory struct {
+	Module  string
+	Version string
+	License string
+	// Added is the first commit that required the module, or that added
+	// the go.mod of the main module. It is nil if no commit did.
+	Added *HistoryCommit
+	// Versions are the version changes of the module, oldest first.
+	Versions []VersionEvent
+	// LicenseChanged is the last commit that changed the license, nil if the
+	// license did not change since the module was added.
+	LicenseChanged *LicenseChange
+	// Unknown lists the versions whose license could not be identified, so
+	// that earlier license changes are not known.
+	Unknown []string
+}
+
+// History is the history of the modules required by a Go module, read along
+// the first-parent commits of the current branch.
+type History struct {
+	MainModule string
+	// MainAdded is the first commit with the go.mod of the main module.
+	MainAdded *HistoryCommit
+
+	root     string
+	commits  []*object.Commit
+	versions map[string][]VersionEvent
+	licenses map[string]string
+}
+
+// ReadHistory reads go.mod and go.sum of the Go module containing dir at each
+// commit of the current branch of the Git repository containing dir.
+func ReadHistory(dir string) (*History, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, err
+	}
+	repo, err := git.PlainOpenWithOptions(dir, &git.PlainOpenOptions{DetectDotGit: true})
+	if err != nil {
+		return nil, fmt.Errorf("unable to open the Git repository of %s: %w", dir, err)
+	}
+	wt, err := repo.Worktree()
+	if err != nil {
+		return nil, err
+	}
+	head, err := resolveCommit(repo, string(plumbing.HEAD))
+	if err != nil {
+		return nil, fmt.Errorf("unable to resolve HEAD: %w", err)
+	}
+	modDir, err := findGoModDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	h := &History{
+		root:     wt.Filesystem.Root(),
+		versions: make(map[string][]VersionEvent),
+		licenses: make(map[string]string),
+	}
+	rel, err := filepath.Rel(h.root, modDir)
+	if err != nil {
+		return nil, err
+	}
+
+	// follow the first parents, then walk the history oldest first
+	for c := head; ; {
+		h.commits = append([]*object.Commit{c}, h.commits...)
+		if c.NumParents() == 0 {
+			break
+		}
+		if c, err = c.Parent(0); err != nil {
+			return nil, err
+		}
+	}
+
+	var previous map[string]string
+	var previousFiles string
+	for _, c := range h.commits {
+		files, err := fileHashes(c, filepath.Join(rel, "go.mod"), filepath.Join(rel, "go.sum"))
+		if err != nil {
+			return nil, err
+		}
+		if previous != nil && files == previousFiles {
+			continue
+		}
+		previousFiles = files
+		mainModule, modules, err := readModules(c, rel)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read the modules at %s: %w", c.Hash, err)
+		}
+		if mainModule != "" {
+			h.MainModule = mainModule
+			if h.MainAdded == nil {
+				added := newHistoryCommit(c)
+				h.MainAdded = &added
+			}
+		}
+		for path, version := range modules {
+			if previous[path] != version {
+				h.versions[path] = append(h.versions[path], VersionEvent{Commit: newHistoryCommit(c), Version: version})
+			}
+		}
+		for path := range previous {
+			if _, ok := modules[path]; !ok {
+				h.versions[path] = append(h.versions[path], VersionEvent{Commit: newHistoryCommit(c)})
+			}
+		}
+		previous = modules
+	}
+	return h, nil
+}
+
+// fileHashes returns the blob hashes of files, relative to the repository
+// root, at a commit. Missing files have an empty hash.
+func fileHashes(c *object.Commit, paths ...string) (string, error) {
+	tree, err := c.Tree()
+	if err != nil {
+		return "", err
+	}
+	hashes := make([]string, len(paths))
+	for idx, path := range paths {
+		entry, err := tree.FindEntry(filepath.ToSlash(path))
+		if err == object.ErrEntryNotFound || err == object.ErrDirectoryNotFound {
+			continue
+		}
+		if err != nil {
+			return "", err
+		}
+		hashes[idx] = entry.Hash.String()
+	}
+	return strings.Join(hashes, " "), nil
+}
+
+// Module returns the history of the module of a result. Licenses of past
+// versions are identified with the classifier from the module cache, see
+// ModuleDir. License files of the main module, and of other modules stored
+// in the repository, are identified at each commit that changed them.
+func (h *History) Module(res LicenseResult, classifier licenses.Classifier, download bool) (ModuleHistory, error) {
+	mod := res.Module
+	if mod == "" {
+		mod = res.Library
+	}
+	mh := ModuleHistory{Module: mod, Version: res.Version, License: res.License}
+	if mod == h.MainModule {
+		mh.Added = h.MainAdded
+	} else {
+		mh.Versions = h.versions[mod]
+		for _, event := range mh.Versions {
+			if event.Version != "" {
+				added := event.Commit
+				mh.Added = &added
+				break
+			}
+		}
+	}
+
+	if rel, ok := h.repoPath(res.Path); ok {
+		return mh, h.fileLicenseChange(&mh, rel, classifier)
+	}
+	return mh, h.versionLicenseChange(&mh, classifier, download)
+}
+
+// repoPath returns the path of a file relative to the repository root, if
+// the file is in the repository.
+func (h *History) repoPath(path string) (string, bool) {
+	if path == "" {
+		return "", false
+	}
+	rel, err := filepath.Rel(h.root, path)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", false
+	}
+	return rel, true
+}
+
+// fileLicenseChange finds the last commit that changed the license of a
+// license file in the repository.
+func (h *History) fileLicenseChange(mh *ModuleHistory, rel string, classifier licenses.Classifier) error {
+	type revision struct {
+		commit *object.Commit
+		hash   string
+	}
+	var revisions []revision
+	for _, c := range h.commits {
+		hash, err := fileHashes(c, rel)
+		if err != nil {
+			return err
+		}
+		if len(revisions) > 0 && revisions[len(revisions)-1].hash == hash {
+			continue
+		}
+		revisions = append(revisions, revision{commit: c, hash: hash})
+	}
+
+	license := func(r revision) (string, error) {
+		if r.hash == "" {
+			return "", nil
+		}
+		if l, ok := h.licenses[r.hash]; ok {
+			return l, nil
+		}
+		contents, _, err := readCommitFile(r.commit, rel)
+		if err != nil {
+			return "", err
+		}
+		l, err := identifyContents(filepath.Base(rel), contents, classifier)
+		if err != nil {
+			return "", err
+		}
+		h.licenses[r.hash] = l
+		return l, nil
+	}
+
+	for idx := len(revisions) - 1; idx > 0; idx-- {
+		to, err := license(revisions[idx])
+		if err != nil {
+			return err
+		}
+		from, err := license(revisions[idx-1])
+		if err != nil {
+			return err
+		}
+		if from != to && from != "" {
+			mh.LicenseChanged = &LicenseChange{Commit: newHistoryCommit(revisions[idx].commit), From: from, To: to}
+			return nil
+		}
+	}
+	return nil
+}
+
+// identifyContents identifies the license of a file's contents, written to a
+// temporary file of the same name for the classifier.
+func identifyContents(name, contents string, classifier licenses.Classifier) (string, error) {
+	dir, err := os.MkdirTemp("", "golicenses-history-")
+	if err != nil {
+		return "", err
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, []byte(contents), 0o600); err != nil {
+		return "", err
+	}
+	license, _, err := classifier.Identify(path)
+	if err != nil {
+		// an unidentified license still differs from an identified one
+		return "unknown", nil
+	}
+	return license, nil
+}
+
+// versionLicenseChange finds the last commit that required a module version
+// with a different license than the version before it.
+func (h *History) versionLicenseChange(mh *ModuleHistory, classifier licenses.Classifier, download bool) error {
+	var events []VersionEvent
+	for _, event := range mh.Versions {
+		if event.Version != "" {
+			events = append(events, event)
+		}
+	}
+	license := func(version string) (string, bool, error) {
+		key := mh.Module + "@" + version
+		if l, ok := h.licenses[key]; ok {
+			return l, true, nil
+		}
+		dir, err := ModuleDir(mh.Module, version, download)
+		if err != nil {
+			return "", false, err
+		}
+		if dir == "" {
+			mh.Unknown = append(mh.Unknown, version)
+			return "", false, nil
+		}
+		l := "unknown"
+		if path, err := licenses.Find(dir, classifier); err == nil {
+			if name, _, err := classifier.Identify(path); err == nil {
+				l = name
+			}
+		}
+		h.licenses[key] = l
+		return l, true, nil
+	}
+
+	for idx := len(events) - 1; idx > 0; idx-- {
+		to, ok, err := license(events[idx].Version)
+		if err != nil || !ok {
+			return err
+		}
+		from, ok, err := license(events[idx-1].Version)
+		if err != nil || !ok {
+			return err
+		}
+		if from != to {
+			mh.LicenseChanged = &LicenseChange{Commit: events[idx].Commit, Version: events[idx].Version, From: from, To: to}
+			return nil
+		}
+	}
+	return nil
+}
+
+// ModuleCache returns the directory of the Go module cache.
+func ModuleCache() string {
+	if dir := os.Getenv("GOMODCACHE"); dir != "" {
+		return dir
+	}
+	gopath := filepath.SplitList(build.Default.GOPATH)
+	if len(gopath) == 0 {
+		return ""
+	}
+	return filepath.Join(gopath[0], "pkg", "mod")
+}
+
+// ModuleDir returns the directory of a module version in the module cache,
+// or an empty string if it is not there. With download, missing versions
+// are downloaded with "go mod download".
+func ModuleDir(path, version string, download bool) (string, error) {
+	escapedPath, err := module.EscapePath(path)
+	if err != nil {
+		return "", err
+	}
+	escapedVersion, err := module.EscapeVersion(version)
+	if err != nil {
+		return "", err
+	}
+	dir := filepath.Join(ModuleCache(), escapedPath+"@"+escapedVersion)
+	if _, err := os.Stat(dir); err == nil {
+		return dir, nil
+	}
+	if !download {
+		return "", nil
+	}
+
+	// download outside of any module, so that no go.mod or go.sum is changed
+	cmd := exec.Command("go", "mod", "download", "-json", path+"@"+version)
+	cmd.Dir = os.TempDir()
+	cmd.Env = append(os.Environ(), "GO111MODULE=on", "GOFLAGS=")
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout, cmd.Stderr = &stdout, &stderr
+	runErr := cmd.Run()
+	var downloaded struct {
+		Dir   string
+		Error string
+	}
+	if err := json.Unmarshal(stdout.Bytes(), &downloaded); err != nil {
+		if runErr != nil {
+			return "", fmt.Errorf("unable to download %s@%s: %w: %s", path, version, runErr, strings.TrimSpace(stderr.String()))
+		}
+		return "", err
+	}
+	if downloaded.Error != "" {
+		return "", fmt.Errorf("unable to download %s@%s: %s", path, version, downloaded.Error)
+	}
+	return downloaded.Dir, nil
+}
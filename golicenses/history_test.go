@@ -0,0 +1,128 @@
+package golicenses
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/khulnasoft/go-licenses/golicenses/licenses"
+	git "gopkg.in/src-d/go-git.v4"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+)
+
+// contentClassifier identifies a license file by its first line.
+type contentClassifier struct{}
+
+func (contentClassifier) Identify(path string) (string, licenses.Type, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return "", "", err
+	}
+	return strings.SplitN(string(content), "\n", 2)[0], licenses.Notice, nil
+}
+
+func TestHistory_Module(t *testing.T) {
+	dir := t.TempDir()
+	repo, err := git.PlainInit(dir, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wt, err := repo.Worktree()
+	if err != nil {
+		t.Fatal(err)
+	}
+	day := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	commit := func(author, message string, files map[string]string) {
+		t.Helper()
+		for name, content := range files {
+			if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+				t.Fatal(err)
+			}
+			if _, err := wt.Add(name); err != nil {
+				t.Fatal(err)
+			}
+		}
+		day = day.AddDate(0, 0, 1)
+		sig := &object.Signature{Name: author, Email: author + "@example.com", When: day}
+		if _, err := wt.Commit(message, &git.CommitOptions{Author: sig}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	goMod := func(requires ...string) string {
+		return "module example.com/app\n\ngo 1.16\n\nrequire (\n\t" + strings.Join(requires, "\n\t") + "\n)\n"
+	}
+	commit("alice", "Initial commit", map[string]string{"go.mod": goMod("github.com/foo/bar v1.0.0"), "LICENSE": "MIT\n"})
+	commit("bob", "Add gpl dependency\n\nDetails.", map[string]string{"go.mod": goMod("github.com/foo/bar v1.0.0", "github.com/gpl/lib v0.1.0")})
+	commit("carol", "Upgrade bar", map[string]string{"go.mod": goMod("github.com/foo/bar v1.1.0", "github.com/gpl/lib v0.1.0")})
+	commit("dave", "Relicense", map[string]string{"LICENSE": "Apache-2.0\n"})
+	commit("erin", "Upgrade bar again", map[string]string{"go.mod": goMod("github.com/foo/bar v1.2.0", "github.com/gpl/lib v0.1.0")})
+
+	cache := t.TempDir()
+	t.Setenv("GOMODCACHE", cache)
+	for mod, license := range map[string]string{
+		"github.com/foo/bar@v1.0.0": "GPL-3.0",
+		"github.com/foo/bar@v1.1.0": "MIT",
+		"github.com/foo/bar@v1.2.0": "MIT",
+	} {
+		modDir := filepath.Join(cache, filepath.FromSlash(mod))
+		if err := os.MkdirAll(modDir, 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(modDir, "LICENSE"), []byte(license+"\n"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	history, err := ReadHistory(dir)
+	if err != nil {
+		t.Fatalf("ReadHistory() error: %v", err)
+	}
+	if history.MainModule != "example.com/app" || history.MainAdded == nil || history.MainAdded.Author != "alice" {
+		t.Errorf("ReadHistory() main module = %s, added %+v", history.MainModule, history.MainAdded)
+	}
+
+	bar, err := history.Module(LicenseResult{Library: "github.com/foo/bar/pkg", Module: "github.com/foo/bar", Version: "v1.2.0", License: "MIT"}, contentClassifier{}, false)
+	if err != nil {
+		t.Fatalf("Module() error: %v", err)
+	}
+	if bar.Added == nil || bar.Added.Author != "alice" || len(bar.Versions) != 3 {
+		t.Errorf("Module(bar) = %+v", bar)
+	}
+	if c := bar.LicenseChanged; c == nil || c.Commit.Author != "carol" || c.Version != "v1.1.0" || c.From != "GPL-3.0" || c.To != "MIT" {
+		t.Errorf("Module(bar) license change = %+v", c)
+	}
+
+	gpl, err := history.Module(LicenseResult{Library: "github.com/gpl/lib", Module: "github.com/gpl/lib", Version: "v0.1.0"}, contentClassifier{}, false)
+	if err != nil {
+		t.Fatalf("Module() error: %v", err)
+	}
+	if gpl.Added == nil || gpl.Added.Author != "bob" || gpl.Added.Summary != "Add gpl dependency" || gpl.LicenseChanged != nil {
+		t.Errorf("Module(gpl) = %+v", gpl)
+	}
+
+	app, err := history.Module(LicenseResult{Library: "example.com/app", Module: "example.com/app", Path: filepath.Join(dir, "LICENSE")}, contentClassifier{}, false)
+	if err != nil {
+		t.Fatalf("Module() error: %v", err)
+	}
+	if c := app.LicenseChanged; c == nil || c.Commit.Author != "dave" || c.From != "MIT" || c.To != "Apache-2.0" {
+		t.Errorf("Module(app) license change = %+v", c)
+	}
+
+	// without the old versions in the module cache, earlier changes are unknown
+	if err := os.RemoveAll(filepath.Join(cache, "github.com", "foo", "bar@v1.0.0")); err != nil {
+		t.Fatal(err)
+	}
+	history, err = ReadHistory(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	bar, err = history.Module(LicenseResult{Library: "github.com/foo/bar", Module: "github.com/foo/bar", Version: "v1.2.0"}, contentClassifier{}, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bar.LicenseChanged != nil || len(bar.Unknown) != 1 || bar.Unknown[0] != "v1.0.0" {
+		t.Errorf("Module(bar) without v1.0.0 = %+v", bar)
+	}
+}
@@ -17,6 +17,7 @@ package licenses
 import (
 	"fmt"
 	"os"
+	"strings"
 
 	"github.com/google/licenseclassifier"
 )
@@ -63,6 +64,20 @@ func (t Type) String() string {
 	}
 }
 
+// ParseType parses a license type name, as printed by Type.String.
+func ParseType(s string) (Type, error) {
+	switch t := Type(strings.ToLower(s)); t {
+	case Restricted, Reciprocal, Notice, Permissive, Unencumbered:
+		return t, nil
+	case Type(strings.ToLower(string(Forbidden))):
+		return Forbidden, nil
+	case Unknown, "unknown":
+		return Unknown, nil
+	default:
+		return Unknown, fmt.Errorf("unknown license type %q", s)
+	}
+}
+
 // Classifier can detect the type of a software license.
 type Classifier interface {
 	Identify(licensePath string) (string, Type, error)
@@ -70,6 +85,8 @@ type Classifier interface {
 
 type googleClassifier struct {
 	classifier *licenseclassifier.License
+	// customTypes are the types of licenses that licenseclassifier doesn't know.
+	customTypes map[string]Type
 }
 
 // NewClassifier creates a classifier that requires a specified confidence threshold
@@ -88,14 +105,49 @@ func (c *googleClassifier) Identify(licensePath string) (string, Type, error) {
 	if licensePath == "" {
 		return "", Unknown, nil
 	}
-	content, err := os.ReadFile(licensePath)
+	text, spdxID, err := classifiableText(licensePath)
 	if err != nil {
 		return "", "", err
 	}
-	matches := c.classifier.MultipleMatch(string(content), true)
+	if spdxID != "" {
+		return spdxID, SPDXType(spdxID), nil
+	}
+	matches := c.classifier.MultipleMatch(text, true)
 	if len(matches) == 0 {
 		return "", "", fmt.Errorf("unknown license")
 	}
 	licenseName := matches[0].Name
+	if t, ok := c.customTypes[licenseName]; ok {
+		return licenseName, t, nil
+	}
 	return licenseName, Type(licenseclassifier.LicenseType(licenseName)), nil
 }
+
+// classifiableText returns the part of a license file that should be
+// classified: the license section of a README, or the header comment of a Go
+// source file. If a Go source file has an explicit SPDX tag, its identifier is
+// returned instead, as it takes precedence over the license header.
+func classifiableText(licensePath string) (text, spdxID string, err error) {
+	content, err := os.ReadFile(licensePath)
+	if err != nil {
+		return "", "", err
+	}
+	text = string(content)
+	switch SourceKindOf(licensePath) {
+	case ReadmeSource:
+		// A whole README is never a good match, only classify its license section.
+		var ok bool
+		if text, ok = ExtractLicenseSection(text); !ok {
+			return "", "", fmt.Errorf("no license section in README")
+		}
+	case SourceHeaderSource:
+		if ids := SPDXIdentifiers(text); len(ids) > 0 {
+			return "", ids[0], nil
+		}
+		var ok bool
+		if text, ok = ExtractHeaderComment(text); !ok {
+			return "", "", fmt.Errorf("no license header")
+		}
+	}
+	return text, "", nil
+}
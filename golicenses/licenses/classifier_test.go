@@ -70,6 +70,27 @@ func TestIdentify(t *testing.T) {
 			wantLicense: "MIT",
 			wantType:    Notice,
 		},
+		{
+			desc:        "README license section",
+			file:        "testdata/readme/README.md",
+			confidence:  0.9,
+			wantLicense: "MIT",
+			wantType:    Notice,
+		},
+		{
+			desc:        "Go source license header",
+			file:        "testdata/direct/direct.go",
+			confidence:  0.9,
+			wantLicense: "Apache-2.0",
+			wantType:    Notice,
+		},
+		{
+			desc:        "Go source SPDX tag",
+			file:        "testdata/spdx/spdx.go",
+			confidence:  1,
+			wantLicense: "MIT",
+			wantType:    Notice,
+		},
 		{
 			desc:       "non-existent file",
 			file:       "non-existent-file",
@@ -101,3 +122,20 @@ func TestIdentify(t *testing.T) {
 		})
 	}
 }
+
+func TestParseType(t *testing.T) {
+	for in, want := range map[string]Type{
+		"notice":     Notice,
+		"Restricted": Restricted,
+		"forbidden":  Forbidden,
+		"unknown":    Unknown,
+		"":           Unknown,
+	} {
+		if got, err := ParseType(in); err != nil || got != want {
+			t.Errorf("ParseType(%q) = (%q, %v), want (%q, <nil>)", in, got, err, want)
+		}
+	}
+	if _, err := ParseType("bogus"); err == nil {
+		t.Error("ParseType(\"bogus\") expected error")
+	}
+}
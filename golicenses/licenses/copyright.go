@@ -0,0 +1,68 @@
+package licenses
+
+import (
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+var (
+	copyrightRegexp = regexp.MustCompile(`(?i)^(?:copyright\b|\(c\)\s*\d{4}|©)`)
+	// copyrightNoiseRegexp matches license text that talks about copyright
+	// rather than stating it, e.g. "Copyright notice" or "Copyright [yyyy] [name]".
+	copyrightNoiseRegexp = regexp.MustCompile(`(?i)^copyright\s*(?:\(c\)\s*)?(?:(?:notice|holders?|owners?|and|law|license|statement)\b|[\[<{])`)
+	copyrightYearRegexp  = regexp.MustCompile(`\d{4}|(?i)\(c\)|©`)
+)
+
+// Copyrights returns the distinct copyright statements found in content, such
+// as "Copyright (c) 2019 Jane Doe", in the order they appear. Comment markers
+// are stripped from the start and end of each line.
+func Copyrights(content string) []string {
+	var statements []string
+	seen := make(map[string]bool)
+	for _, line := range strings.Split(content, "\n") {
+		line = strings.TrimSpace(line)
+		line = strings.TrimLeft(line, "/*#;- \t")
+		line = strings.TrimSpace(strings.TrimSuffix(line, "*/"))
+		if !copyrightRegexp.MatchString(line) || copyrightNoiseRegexp.MatchString(line) {
+			continue
+		}
+		if !copyrightYearRegexp.MatchString(line) {
+			continue
+		}
+		line = strings.Join(strings.Fields(line), " ")
+		if !seen[line] {
+			seen[line] = true
+			statements = append(statements, line)
+		}
+	}
+	return statements
+}
+
+// libraryCopyrights collects the copyright statements of a library from its
+// license file, its NOTICE files, and the header comments of its Go source
+// files, in that order and without duplicates.
+func libraryCopyrights(licensePath string, noticePaths, goFiles []string) []string {
+	var texts []string
+	for _, path := range append([]string{licensePath}, noticePaths...) {
+		if path == "" {
+			continue
+		}
+		if content, err := os.ReadFile(path); err == nil {
+			texts = append(texts, string(content))
+		}
+	}
+	files := append([]string(nil), goFiles...)
+	sort.Strings(files)
+	for _, f := range files {
+		content, err := os.ReadFile(f)
+		if err != nil {
+			continue
+		}
+		if header, ok := ExtractHeaderComment(string(content)); ok {
+			texts = append(texts, header)
+		}
+	}
+	return Copyrights(strings.Join(texts, "\n"))
+}
@@ -0,0 +1,67 @@
+package licenses
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestCopyrights(t *testing.T) {
+	for _, test := range []struct {
+		desc    string
+		content string
+		want    []string
+	}{
+		{
+			desc:    "license file",
+			content: "MIT License\n\nCopyright (c) 2019  Jane Doe\n\nPermission is hereby granted...\nThe above copyright notice and this permission notice shall be included\n",
+			want:    []string{"Copyright (c) 2019 Jane Doe"},
+		},
+		{
+			desc:    "source header comments",
+			content: "// Copyright 2019 Google Inc. All Rights Reserved.\n/*\n * Copyright © The Foo Authors */\n",
+			want:    []string{"Copyright 2019 Google Inc. All Rights Reserved.", "Copyright © The Foo Authors"},
+		},
+		{
+			desc:    "license template text is ignored",
+			content: "   Copyright [yyyy] [name of copyright owner]\nCopyright notice\nCopyright holders may\nCopyright Foo\n",
+		},
+		{
+			desc:    "names starting like template words",
+			content: "Copyright (c) Andrew Smith\nCopyright Lawrence Livermore 2020\nCopyright Ownership Labs 2021\nCopyright Holderness Inc (c)\nCopyright (c) and licensed under\n",
+			want:    []string{"Copyright (c) Andrew Smith", "Copyright Lawrence Livermore 2020", "Copyright Ownership Labs 2021", "Copyright Holderness Inc (c)"},
+		},
+		{
+			desc:    "duplicates",
+			content: "(c) 2020 Foo\n(c) 2020 Foo\n",
+			want:    []string{"(c) 2020 Foo"},
+		},
+	} {
+		t.Run(test.desc, func(t *testing.T) {
+			if diff := cmp.Diff(test.want, Copyrights(test.content)); diff != "" {
+				t.Errorf("Copyrights(): diff (-want +got)\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestLibraryCopyrights(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+	licensePath := write("LICENSE", "Copyright (c) 2019 Jane Doe\n")
+	noticePath := write("NOTICE", "Foo\nCopyright 2020 Foo Corp\n")
+	goFile := write("foo.go", "// Copyright 2021 John Doe\n\npackage foo\n\n// Copyright 2022 not a header\n")
+
+	want := []string{"Copyright (c) 2019 Jane Doe", "Copyright 2020 Foo Corp", "Copyright 2021 John Doe"}
+	if diff := cmp.Diff(want, libraryCopyrights(licensePath, []string{noticePath}, []string{goFile})); diff != "" {
+		t.Errorf("libraryCopyrights(): diff (-want +got)\n%s", diff)
+	}
+}
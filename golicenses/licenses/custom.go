@@ -0,0 +1,178 @@
+package licenses
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/google/licensecheck"
+	"github.com/google/licenseclassifier"
+)
+
+var wordRegexp = regexp.MustCompile(`[\p{L}\p{N}]+`)
+
+// CustomLicensesConfig configures license texts that are not part of the
+// license database, like vendor EULAs or a corporate license.
+type CustomLicensesConfig struct {
+	// Dir is the directory holding the license texts.
+	Dir      string                `mapstructure:"dir"`
+	Licenses []CustomLicenseConfig `mapstructure:"licenses"`
+}
+
+// CustomLicenseConfig declares a single custom license.
+type CustomLicenseConfig struct {
+	Name string `mapstructure:"name"`
+	// Type is the license type, e.g. "restricted" (see ParseType).
+	Type string `mapstructure:"type"`
+	// File is the license text file relative to the directory, "<name>.txt" by default.
+	File string `mapstructure:"file"`
+}
+
+// CustomLicense is a license text with its name and type.
+type CustomLicense struct {
+	Name string
+	Type Type
+	Text string
+}
+
+// Validate checks that every custom license has a distinct name and a known
+// license type, so that type rules can classify it.
+func (c CustomLicensesConfig) Validate() error {
+	seen := make(map[string]bool)
+	for _, l := range c.Licenses {
+		if l.Name == "" {
+			return fmt.Errorf("custom license without a name")
+		}
+		if seen[l.Name] {
+			return fmt.Errorf("custom license %q is declared twice", l.Name)
+		}
+		seen[l.Name] = true
+		if l.Type == "" {
+			return fmt.Errorf("custom license %q has no type", l.Name)
+		}
+		licenseType, err := ParseType(l.Type)
+		if err != nil {
+			return fmt.Errorf("custom license %q: %w", l.Name, err)
+		}
+		if licenseType == Unknown {
+			return fmt.Errorf("custom license %q: type %q is not a known license type", l.Name, l.Type)
+		}
+	}
+	return nil
+}
+
+// Load reads the configured license texts (see Validate).
+func (c CustomLicensesConfig) Load() ([]CustomLicense, error) {
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+	var custom []CustomLicense
+	for _, l := range c.Licenses {
+		licenseType, err := ParseType(l.Type)
+		if err != nil {
+			return nil, fmt.Errorf("custom license %q: %w", l.Name, err)
+		}
+		file := l.File
+		if file == "" {
+			file = l.Name + ".txt"
+		}
+		content, err := os.ReadFile(filepath.Join(c.Dir, file))
+		if err != nil {
+			return nil, fmt.Errorf("custom license %q: %w", l.Name, err)
+		}
+		custom = append(custom, CustomLicense{Name: l.Name, Type: licenseType, Text: string(content)})
+	}
+	return custom, nil
+}
+
+// NewCustomClassifier creates a classifier like NewClassifier, with the
+// custom licenses added to the license archive returned by archive.
+func NewCustomClassifier(confidenceThreshold float64, archive func() ([]byte, error), custom []CustomLicense) (Classifier, error) {
+	merged := func() ([]byte, error) {
+		db, err := archive()
+		if err != nil {
+			return nil, err
+		}
+		return appendLicenses(db, custom)
+	}
+	c, err := licenseclassifier.New(confidenceThreshold, licenseclassifier.ArchiveFunc(merged))
+	if err != nil {
+		return nil, err
+	}
+	return &googleClassifier{classifier: c, customTypes: customTypes(custom)}, nil
+}
+
+// customTypes maps the names of custom licenses to their types.
+func customTypes(custom []CustomLicense) map[string]Type {
+	types := make(map[string]Type, len(custom))
+	for _, l := range custom {
+		types[l.Name] = l.Type
+	}
+	return types
+}
+
+// appendLicenses returns a copy of a license archive with the custom licenses added.
+func appendLicenses(archive []byte, custom []CustomLicense) ([]byte, error) {
+	gr, err := gzip.NewReader(bytes.NewReader(archive))
+	if err != nil {
+		return nil, err
+	}
+	defer gr.Close()
+
+	var out bytes.Buffer
+	gw := gzip.NewWriter(&out)
+	tw := tar.NewWriter(gw)
+	builtin := make(map[string]bool)
+	tr := tar.NewReader(gr)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		builtin[strings.TrimSuffix(hdr.Name, ".txt")] = true
+		if err := tw.WriteHeader(hdr); err != nil {
+			return nil, err
+		}
+		if _, err := io.Copy(tw, tr); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, l := range custom {
+		if builtin[l.Name] {
+			return nil, fmt.Errorf("custom license %q conflicts with a license of the database", l.Name)
+		}
+		if err := writeLicense(tw, l.Name, l.Text); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		return nil, err
+	}
+	if err := gw.Close(); err != nil {
+		return nil, err
+	}
+	return out.Bytes(), nil
+}
+
+// licensecheckLicenses converts custom licenses to licensecheck patterns.
+// Punctuation is ignored by licensecheck, so the pattern is just the words
+// of the text, which also avoids accidental pattern syntax.
+func licensecheckLicenses(custom []CustomLicense) []licensecheck.License {
+	list := make([]licensecheck.License, 0, len(custom))
+	for _, l := range custom {
+		words := wordRegexp.FindAllString(l.Text, -1)
+		list = append(list, licensecheck.License{ID: l.Name, LRE: strings.Join(words, " ")})
+	}
+	return list
+}
@@ -0,0 +1,91 @@
+package licenses
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/licenseclassifier"
+)
+
+var acmeConfig = CustomLicensesConfig{
+	Dir:      "testdata/custom/licenses",
+	Licenses: []CustomLicenseConfig{{Name: "ACME-EULA", Type: "restricted"}},
+}
+
+func TestCustomLicensesLoad(t *testing.T) {
+	custom, err := acmeConfig.Load()
+	if err != nil {
+		t.Fatalf("Load() error: %v", err)
+	}
+	if len(custom) != 1 || custom[0].Name != "ACME-EULA" || custom[0].Type != Restricted || !strings.Contains(custom[0].Text, "ACME Corporation") {
+		t.Errorf("Load() = %+v, want the ACME-EULA text as restricted", custom)
+	}
+
+	for _, test := range []struct {
+		desc    string
+		license CustomLicenseConfig
+		wantErr string
+	}{
+		{desc: "no name", license: CustomLicenseConfig{Type: "notice"}, wantErr: "without a name"},
+		{desc: "bad type", license: CustomLicenseConfig{Name: "ACME-EULA", Type: "proprietary"}, wantErr: "unknown license type"},
+		{desc: "no type", license: CustomLicenseConfig{Name: "ACME-EULA"}, wantErr: "has no type"},
+		{desc: "unknown type", license: CustomLicenseConfig{Name: "ACME-EULA", Type: "unknown"}, wantErr: "not a known license type"},
+		{desc: "missing file", license: CustomLicenseConfig{Name: "Other", Type: "notice"}, wantErr: "Other.txt"},
+	} {
+		t.Run(test.desc, func(t *testing.T) {
+			config := CustomLicensesConfig{Dir: acmeConfig.Dir, Licenses: []CustomLicenseConfig{test.license}}
+			if _, err := config.Load(); err == nil || !strings.Contains(err.Error(), test.wantErr) {
+				t.Errorf("Load() error = %v, want %q", err, test.wantErr)
+			}
+		})
+	}
+
+	twice := CustomLicensesConfig{Dir: acmeConfig.Dir, Licenses: append(acmeConfig.Licenses, acmeConfig.Licenses...)}
+	if _, err := twice.Load(); err == nil || !strings.Contains(err.Error(), "declared twice") {
+		t.Errorf("Load() error = %v, want duplicate error", err)
+	}
+}
+
+func TestCustomClassifiers(t *testing.T) {
+	custom, err := acmeConfig.Load()
+	if err != nil {
+		t.Fatalf("Load() error: %v", err)
+	}
+	archive := func() ([]byte, error) {
+		return licenseclassifier.ReadLicenseFile(licenseclassifier.LicenseArchive)
+	}
+	google, err := NewCustomClassifier(0.9, archive, custom)
+	if err != nil {
+		t.Fatalf("NewCustomClassifier() error: %v", err)
+	}
+	licensecheck, err := NewLicensecheckClassifier(0.9, custom...)
+	if err != nil {
+		t.Fatalf("NewLicensecheckClassifier() error: %v", err)
+	}
+
+	for name, c := range map[string]Classifier{"google": google, "licensecheck": licensecheck} {
+		for _, test := range []struct {
+			file        string
+			wantLicense string
+			wantType    Type
+		}{
+			{file: "testdata/custom/vendor/LICENSE", wantLicense: "ACME-EULA", wantType: Restricted},
+			{file: "testdata/MIT/LICENSE.MIT", wantLicense: "MIT", wantType: Notice},
+		} {
+			gotLicense, gotType, err := c.Identify(test.file)
+			if err != nil || gotLicense != test.wantLicense || gotType != test.wantType {
+				t.Errorf("%s: c.Identify(%q) = (%q, %q, %v), want (%q, %q, <nil>)", name, test.file, gotLicense, gotType, err, test.wantLicense, test.wantType)
+			}
+		}
+	}
+}
+
+func TestCustomClassifierConflict(t *testing.T) {
+	archive := func() ([]byte, error) {
+		return licenseclassifier.ReadLicenseFile(licenseclassifier.LicenseArchive)
+	}
+	_, err := NewCustomClassifier(0.9, archive, []CustomLicense{{Name: "MIT", Type: Restricted, Text: "not MIT"}})
+	if err == nil || !strings.Contains(err.Error(), "conflicts") {
+		t.Errorf("NewCustomClassifier() error = %v, want conflict error", err)
+	}
+}
@@ -0,0 +1,165 @@
+package licenses
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/licenseclassifier"
+	"github.com/google/licenseclassifier/stringclassifier/searchset"
+)
+
+// dbVersionPrefix marks the gzip header comment of archives built by BuildDB.
+// The archive itself can't hold metadata, licenseclassifier reads every tar
+// entry as part of a license.
+const dbVersionPrefix = "license-list-data "
+
+// DBInfo describes a license archive, as read by licenseclassifier.
+type DBInfo struct {
+	// Version is the SPDX license list version the archive was built from, if known.
+	Version string
+	// Created is when the archive was built, if known.
+	Created  time.Time
+	SHA256   string
+	Size     int
+	Licenses []DBLicense
+}
+
+// DBLicense is a license known by a license archive.
+type DBLicense struct {
+	Name string
+	Type Type
+}
+
+// ReadDBInfo lists the licenses of a license archive, sorted by name.
+func ReadDBInfo(archive []byte) (DBInfo, error) {
+	sum := sha256.Sum256(archive)
+	info := DBInfo{SHA256: hex.EncodeToString(sum[:]), Size: len(archive)}
+
+	gr, err := gzip.NewReader(bytes.NewReader(archive))
+	if err != nil {
+		return DBInfo{}, fmt.Errorf("invalid license archive: %w", err)
+	}
+	defer gr.Close()
+	if strings.HasPrefix(gr.Comment, dbVersionPrefix) {
+		info.Version = strings.TrimPrefix(gr.Comment, dbVersionPrefix)
+		info.Created = gr.ModTime
+	}
+
+	tr := tar.NewReader(gr)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return DBInfo{}, fmt.Errorf("invalid license archive: %w", err)
+		}
+		if name := strings.TrimSuffix(hdr.Name, ".txt"); name != hdr.Name {
+			info.Licenses = append(info.Licenses, DBLicense{Name: name, Type: Type(licenseclassifier.LicenseType(name))})
+		}
+	}
+	sort.Slice(info.Licenses, func(i, j int) bool {
+		return info.Licenses[i].Name < info.Licenses[j].Name
+	})
+	return info, nil
+}
+
+// BuildDB writes a license archive with the license texts of dir, named
+// "<license>.txt" like the text directory of SPDX license-list-data.
+// Deprecated licenses are skipped. If version is empty, it is read from the
+// licenses.json of license-list-data, next to dir or in its parent.
+// It returns the number of licenses written.
+func BuildDB(w io.Writer, dir, version string) (int, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.txt"))
+	if err != nil {
+		return 0, err
+	}
+	sort.Strings(paths)
+	if version == "" {
+		version = licenseListVersion(dir)
+	}
+
+	gw := gzip.NewWriter(w)
+	gw.Comment = dbVersionPrefix + version
+	gw.ModTime = time.Now().UTC()
+	tw := tar.NewWriter(gw)
+	count := 0
+	for _, path := range paths {
+		name := strings.TrimSuffix(filepath.Base(path), ".txt")
+		if strings.HasPrefix(name, "deprecated_") {
+			continue
+		}
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return 0, err
+		}
+		if err := writeLicense(tw, name, string(content)); err != nil {
+			return 0, fmt.Errorf("unable to add %s: %w", name, err)
+		}
+		count++
+	}
+	if count == 0 {
+		return 0, fmt.Errorf("no license texts (*.txt) found in %s", dir)
+	}
+	if err := tw.Close(); err != nil {
+		return 0, err
+	}
+	return count, gw.Close()
+}
+
+// licenseListVersion reads the SPDX license list version from licenses.json,
+// or returns "unknown".
+func licenseListVersion(dir string) string {
+	for _, path := range []string{
+		filepath.Join(dir, "licenses.json"),
+		filepath.Join(dir, "..", "json", "licenses.json"),
+	} {
+		content, err := os.ReadFile(path)
+		if err != nil {
+			continue
+		}
+		var list struct {
+			LicenseListVersion string `json:"licenseListVersion"`
+		}
+		if json.Unmarshal(content, &list) == nil && list.LicenseListVersion != "" {
+			return list.LicenseListVersion
+		}
+	}
+	return "unknown"
+}
+
+// writeLicense adds a license to an archive: its normalized text and the
+// hashes of its substrings, the same way the licenseclassifier serializer does.
+func writeLicense(tw *tar.Writer, name, text string) error {
+	text = licenseclassifier.TrimExtraneousTrailingText(text)
+	for _, n := range licenseclassifier.Normalizers {
+		text = n(text)
+	}
+	var hashes bytes.Buffer
+	if err := searchset.New(text, searchset.DefaultGranularity).Serialize(&hashes); err != nil {
+		return err
+	}
+	if err := writeTarFile(tw, name+".txt", []byte(text)); err != nil {
+		return err
+	}
+	return writeTarFile(tw, name+".hash", hashes.Bytes())
+}
+
+func writeTarFile(tw *tar.Writer, name string, content []byte) error {
+	if err := tw.WriteHeader(&tar.Header{Name: name, Mode: 0644, Size: int64(len(content))}); err != nil {
+		return err
+	}
+	_, err := tw.Write(content)
+	return err
+}
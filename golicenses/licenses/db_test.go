@@ -0,0 +1,88 @@
+package licenses
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-test/deep"
+	"github.com/google/licenseclassifier"
+)
+
+func TestBuildDB(t *testing.T) {
+	root := t.TempDir()
+	textDir := filepath.Join(root, "text")
+	for name, src := range map[string]string{
+		"MIT.txt":            "testdata/MIT/LICENSE.MIT",
+		"Apache-2.0.txt":     "testdata/LICENSE",
+		"deprecated_Old.txt": "testdata/MIT/LICENSE.MIT",
+	} {
+		content, err := os.ReadFile(src)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := os.MkdirAll(textDir, 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(textDir, name), content, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.MkdirAll(filepath.Join(root, "json"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(root, "json", "licenses.json"), []byte(`{"licenseListVersion": "3.24"}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	var db bytes.Buffer
+	count, err := BuildDB(&db, textDir, "")
+	if err != nil {
+		t.Fatalf("BuildDB() error: %v", err)
+	}
+	if count != 2 {
+		t.Errorf("BuildDB() = %d licenses, want 2", count)
+	}
+
+	info, err := ReadDBInfo(db.Bytes())
+	if err != nil {
+		t.Fatalf("ReadDBInfo() error: %v", err)
+	}
+	if info.Version != "3.24" || info.Created.IsZero() || info.Size != db.Len() || info.SHA256 == "" {
+		t.Errorf("ReadDBInfo() = %+v, want version 3.24 with creation time, size and checksum", info)
+	}
+	expected := []DBLicense{{Name: "Apache-2.0", Type: Notice}, {Name: "MIT", Type: Notice}}
+	for _, d := range deep.Equal(expected, info.Licenses) {
+		t.Errorf("diff: %+v", d)
+	}
+
+	c, err := NewClassifier(0.9, licenseclassifier.ArchiveBytes(db.Bytes()))
+	if err != nil {
+		t.Fatalf("NewClassifier() with built database error: %v", err)
+	}
+	if name, licenseType, err := c.Identify("testdata/MIT/LICENSE.MIT"); err != nil || name != "MIT" || licenseType != Notice {
+		t.Errorf("c.Identify() = (%q, %q, %v), want (MIT, notice, <nil>)", name, licenseType, err)
+	}
+
+	if _, err := BuildDB(&db, t.TempDir(), "1.0"); err == nil {
+		t.Error("BuildDB() expected error for a directory without license texts")
+	}
+}
+
+func TestReadDBInfoEmbedded(t *testing.T) {
+	archive, err := licenseclassifier.ReadLicenseFile(licenseclassifier.LicenseArchive)
+	if err != nil {
+		t.Fatal(err)
+	}
+	info, err := ReadDBInfo(archive)
+	if err != nil {
+		t.Fatalf("ReadDBInfo() error: %v", err)
+	}
+	if info.Version != "" || len(info.Licenses) == 0 {
+		t.Errorf("ReadDBInfo() = version %q with %d licenses, want unknown version with licenses", info.Version, len(info.Licenses))
+	}
+	if _, err := ReadDBInfo([]byte("not an archive")); err == nil {
+		t.Error("ReadDBInfo() expected error for an invalid archive")
+	}
+}
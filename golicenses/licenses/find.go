@@ -34,6 +34,42 @@ var (
 	vendorRegexp = regexp.MustCompile(`.+/vendor(/)?$`)
 )
 
+// SourceKind describes what kind of file a license was identified from.
+type SourceKind string
+
+// License source kinds
+const (
+	// UnknownSource is used when no license was found.
+	UnknownSource = SourceKind("")
+	// LicenseFileSource is a dedicated license file, such as LICENSE or COPYING.
+	LicenseFileSource = SourceKind("license-file")
+	// ReadmeSource is the license section of a README file.
+	ReadmeSource = SourceKind("readme")
+	// SourceHeaderSource is an SPDX-License-Identifier tag or a license header
+	// comment in a Go source file.
+	SourceHeaderSource = SourceKind("source-header")
+)
+
+// SourceKindOf returns the kind of license source for a license path returned by Find.
+func SourceKindOf(licensePath string) SourceKind {
+	switch {
+	case licensePath == "":
+		return UnknownSource
+	case IsReadme(licensePath):
+		return ReadmeSource
+	case IsGoSource(licensePath):
+		return SourceHeaderSource
+	default:
+		return LicenseFileSource
+	}
+}
+
+// IsSecondary reports whether licenses from this kind of source did not come
+// from a dedicated license file, which reports point out.
+func (k SourceKind) IsSecondary() bool {
+	return k != UnknownSource && k != LicenseFileSource
+}
+
 // Find returns the file path of the license for this package.
 func Find(dir string, classifier Classifier) (string, error) {
 	var stopAt []*regexp.Regexp
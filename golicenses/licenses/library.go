@@ -44,6 +44,30 @@ type Library struct {
 	// Packages contains import paths for Go packages in this library.
 	// It may not be the complete set of all packages in the library.
 	Packages []string
+	// SPDXIdentifiers contains the distinct SPDX-License-Identifier expressions
+	// declared by the Go source files of the library's packages.
+	// More than one entry means the files disagree about their license.
+	SPDXIdentifiers []string
+	// Copyrights contains the copyright statements found in the library's
+	// license file, NOTICE files and Go source file headers.
+	Copyrights []string
+	// NoticePaths contains the paths of the NOTICE files in the library's module.
+	NoticePaths []string
+	// Module is the Go module containing the library, if known.
+	Module *Module
+	// DependsOn contains the modules (or import paths of packages without a
+	// module) imported by the library's packages, except its own module.
+	DependsOn []string
+}
+
+// Module identifies the Go module that contains a library.
+type Module struct {
+	// Path is the module path, e.g. "github.com/google/trillian".
+	Path string
+	// Version is the module version. It is empty for the main module.
+	Version string
+	// Dir is the directory holding the module's files.
+	Dir string
 }
 
 // PackagesError aggregates all Packages[].Errors into a single error.
@@ -69,7 +93,7 @@ func (e PackagesError) Error() string {
 func Libraries(ctx context.Context, classifier Classifier, importPaths ...string) ([]*Library, error) {
 	cfg := &packages.Config{
 		Context: ctx,
-		Mode:    packages.NeedImports | packages.NeedDeps | packages.NeedFiles | packages.NeedName,
+		Mode:    packages.NeedImports | packages.NeedDeps | packages.NeedFiles | packages.NeedName | packages.NeedModule,
 	}
 
 	rootPkgs, err := packages.Load(cfg, importPaths...)
@@ -106,7 +130,10 @@ func Libraries(ctx context.Context, classifier Classifier, importPaths ...string
 		}
 		licensePath, err := Find(pkgDir, classifier)
 		if err != nil {
-			glog.Errorf("Failed to find license for %s: %v", p.PkgPath, err)
+			// Fall back to SPDX tags and license headers in the package's source files.
+			if licensePath = FindInSource(p.GoFiles, classifier); licensePath == "" {
+				glog.Errorf("Failed to find license for %s: %v", p.PkgPath, err)
+			}
 		}
 		pkgs[p.PkgPath] = p
 		pkgsByLicense[licensePath] = append(pkgsByLicense[licensePath], p)
@@ -118,26 +145,102 @@ func Libraries(ctx context.Context, classifier Classifier, importPaths ...string
 		}
 	}
 
+	// Libraries of the same module share their NOTICE files, only search once.
+	noticesByRoot := make(map[string][]string)
+	findNotices := func(root string) []string {
+		if notices, ok := noticesByRoot[root]; ok {
+			return notices
+		}
+		notices, err := FindNotices(root)
+		if err != nil {
+			glog.Warningf("Failed to search for NOTICE files in %s: %v", root, err)
+		}
+		noticesByRoot[root] = notices
+		return notices
+	}
+
 	libraries := make([]*Library, 0)
 	for licensePath, pkgs := range pkgsByLicense {
 		if licensePath == "" {
 			// No license for these packages - return each one as a separate library.
 			for _, p := range pkgs {
-				libraries = append(libraries, &Library{
-					Packages: []string{p.PkgPath},
-				})
+				libraries = append(libraries, newLibrary("", []*packages.Package{p}, findNotices))
 			}
 			continue
 		}
-		lib := &Library{
-			LicensePath: licensePath,
+		libraries = append(libraries, newLibrary(licensePath, pkgs, findNotices))
+	}
+	return libraries, nil
+}
+
+// newLibrary creates a library from packages that share a license file,
+// gathering the license metadata declared by their module and source files.
+func newLibrary(licensePath string, pkgs []*packages.Package, findNotices func(root string) []string) *Library {
+	lib := &Library{
+		LicensePath: licensePath,
+		Module:      moduleOf(pkgs[0]),
+	}
+	var goFiles []string
+	for _, pkg := range pkgs {
+		lib.Packages = append(lib.Packages, pkg.PkgPath)
+		goFiles = append(goFiles, pkg.GoFiles...)
+	}
+	lib.DependsOn = importedModules(lib.Module, pkgs)
+	noticeRoot := ""
+	if lib.Module != nil && lib.Module.Dir != "" {
+		noticeRoot = lib.Module.Dir
+	} else if licensePath != "" {
+		noticeRoot = filepath.Dir(licensePath)
+	}
+	if noticeRoot != "" {
+		lib.NoticePaths = findNotices(noticeRoot)
+	}
+	lib.SPDXIdentifiers = declaredIdentifiers(goFiles)
+	lib.Copyrights = libraryCopyrights(licensePath, lib.NoticePaths, goFiles)
+	return lib
+}
+
+// importedModules returns the sorted modules imported by packages, other than
+// the standard library and the given module.
+func importedModules(own *Module, pkgs []*packages.Package) []string {
+	seen := make(map[string]bool)
+	var modules []string
+	for _, pkg := range pkgs {
+		for _, imp := range pkg.Imports {
+			if isStdLib(imp) {
+				continue
+			}
+			name := imp.PkgPath
+			if imp.Module != nil {
+				name = imp.Module.Path
+			}
+			if (own != nil && name == own.Path) || seen[name] {
+				continue
+			}
+			seen[name] = true
+			modules = append(modules, name)
+		}
+	}
+	sort.Strings(modules)
+	return modules
+}
+
+// moduleOf returns the module of a package, following replace directives.
+func moduleOf(pkg *packages.Package) *Module {
+	m := pkg.Module
+	if m == nil {
+		return nil
+	}
+	mod := &Module{Path: m.Path, Version: m.Version, Dir: m.Dir}
+	if r := m.Replace; r != nil {
+		if r.Version != "" {
+			mod.Version = r.Version
 		}
-		for _, pkg := range pkgs {
-			lib.Packages = append(lib.Packages, pkg.PkgPath)
+		if r.Dir != "" {
+			mod.Dir = r.Dir
 		}
-		libraries = append(libraries, lib)
 	}
-	return libraries, nil
+	return mod
 }
 
 // Name is the common prefix of the import paths for all of the packages in this library.
@@ -16,11 +16,14 @@ package licenses
 
 import (
 	"context"
+	"go/build"
 	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
+	"golang.org/x/tools/go/packages"
 )
 
 func TestLibraries(t *testing.T) {
@@ -90,6 +93,59 @@ func TestLibraries(t *testing.T) {
 	}
 }
 
+func TestLibrariesSourceFallback(t *testing.T) {
+	classifier := classifierStub{
+		licenseNames: map[string]string{
+			"testdata/spdx/other.go": "Apache-2.0",
+			"testdata/spdx/spdx.go":  "MIT",
+		},
+		licenseTypes: map[string]Type{
+			"testdata/spdx/other.go": Notice,
+			"testdata/spdx/spdx.go":  Notice,
+		},
+	}
+	importPath := "github.com/khulnasoft/go-licenses/golicenses/licenses/testdata/spdx"
+	libs, err := Libraries(context.Background(), classifier, importPath)
+	if err != nil {
+		t.Fatalf("Libraries(_, %q) = (_, %q), want (_, nil)", importPath, err)
+	}
+	if len(libs) != 1 {
+		t.Fatalf("Libraries(_, %q) returned %d libraries, want 1", importPath, len(libs))
+	}
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Cannot get working directory: %v", err)
+	}
+	if want := filepath.Join(wd, "testdata/spdx/other.go"); libs[0].LicensePath != want {
+		t.Errorf("LicensePath = %q, want %q", libs[0].LicensePath, want)
+	}
+	if diff := cmp.Diff([]string{"Apache-2.0", "MIT"}, libs[0].SPDXIdentifiers); diff != "" {
+		t.Errorf("SPDXIdentifiers: diff (-want +got)\n%s", diff)
+	}
+}
+
+func TestImportedModules(t *testing.T) {
+	own := &Module{Path: "github.com/google/trillian"}
+	fmtPkg := &packages.Package{PkgPath: "fmt", GoFiles: []string{filepath.Join(build.Default.GOROOT, "src", "fmt", "print.go")}}
+	sibling := &packages.Package{PkgPath: "github.com/google/trillian/types", Module: &packages.Module{Path: own.Path}}
+	glog := &packages.Package{PkgPath: "github.com/golang/glog", Module: &packages.Module{Path: "github.com/golang/glog"}}
+	grpcCodes := &packages.Package{PkgPath: "google.golang.org/grpc/codes", Module: &packages.Module{Path: "google.golang.org/grpc"}}
+	grpcStatus := &packages.Package{PkgPath: "google.golang.org/grpc/status", Module: &packages.Module{Path: "google.golang.org/grpc"}}
+	gopath := &packages.Package{PkgPath: "example.com/nomodule"}
+	pkgs := []*packages.Package{
+		{PkgPath: "github.com/google/trillian/server", Imports: map[string]*packages.Package{
+			"fmt": fmtPkg, sibling.PkgPath: sibling, grpcCodes.PkgPath: grpcCodes, glog.PkgPath: glog,
+		}},
+		{PkgPath: "github.com/google/trillian/client", Imports: map[string]*packages.Package{
+			grpcStatus.PkgPath: grpcStatus, gopath.PkgPath: gopath,
+		}},
+	}
+	want := []string{"example.com/nomodule", "github.com/golang/glog", "google.golang.org/grpc"}
+	if diff := cmp.Diff(want, importedModules(own, pkgs)); diff != "" {
+		t.Errorf("importedModules(): diff (-want +got)\n%s", diff)
+	}
+}
+
 func TestLibraryName(t *testing.T) {
 	for _, test := range []struct {
 		desc     string
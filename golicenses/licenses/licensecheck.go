@@ -0,0 +1,57 @@
+package licenses
+
+import (
+	"fmt"
+
+	"github.com/google/licensecheck"
+)
+
+// licensecheckClassifier identifies licenses with github.com/google/licensecheck,
+// which matches normalized words against its built-in set of SPDX licenses.
+type licensecheckClassifier struct {
+	scanner *licensecheck.Scanner
+	// minCoverage is the percentage of the text that must be covered by known licenses.
+	minCoverage float64
+	// customTypes are the types of licenses that licensecheck doesn't know.
+	customTypes map[string]Type
+}
+
+// NewLicensecheckClassifier creates a classifier backed by licensecheck. It
+// requires that at least confidenceThreshold (0-1) of the license text is
+// covered by known licenses in order to return a positive classification.
+// Custom licenses are recognized in addition to the built-in ones.
+func NewLicensecheckClassifier(confidenceThreshold float64, custom ...CustomLicense) (Classifier, error) {
+	scanner, err := licensecheck.NewScanner(append(licensecheck.BuiltinLicenses(), licensecheckLicenses(custom)...))
+	if err != nil {
+		return nil, err
+	}
+	return &licensecheckClassifier{
+		scanner:     scanner,
+		minCoverage: confidenceThreshold * 100,
+		customTypes: customTypes(custom),
+	}, nil
+}
+
+// Identify returns the name and type of a license, given its file path.
+// An empty license path results in an empty name and Unknown type.
+func (c *licensecheckClassifier) Identify(licensePath string) (string, Type, error) {
+	if licensePath == "" {
+		return "", Unknown, nil
+	}
+	text, spdxID, err := classifiableText(licensePath)
+	if err != nil {
+		return "", "", err
+	}
+	if spdxID != "" {
+		return spdxID, SPDXType(spdxID), nil
+	}
+	cov := c.scanner.Scan([]byte(text))
+	if len(cov.Match) == 0 || cov.Percent < c.minCoverage {
+		return "", "", fmt.Errorf("unknown license")
+	}
+	licenseName := cov.Match[0].ID
+	if t, ok := c.customTypes[licenseName]; ok {
+		return licenseName, t, nil
+	}
+	return licenseName, SPDXType(licenseName), nil
+}
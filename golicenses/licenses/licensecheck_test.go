@@ -0,0 +1,79 @@
+package licenses
+
+import "testing"
+
+func TestLicensecheckIdentify(t *testing.T) {
+	for _, test := range []struct {
+		desc        string
+		file        string
+		confidence  float64
+		wantLicense string
+		wantType    Type
+		wantErr     bool
+	}{
+		{
+			desc:        "Apache 2.0 license",
+			file:        "testdata/LICENSE",
+			confidence:  0.9,
+			wantLicense: "Apache-2.0",
+			wantType:    Notice,
+		},
+		{
+			desc:        "MIT license",
+			file:        "testdata/MIT/LICENSE.MIT",
+			confidence:  0.9,
+			wantLicense: "MIT",
+			wantType:    Notice,
+		},
+		{
+			desc:        "README license section",
+			file:        "testdata/readme/README.md",
+			confidence:  0.9,
+			wantLicense: "MIT",
+			wantType:    Notice,
+		},
+		{
+			desc:        "Go source SPDX tag",
+			file:        "testdata/spdx/spdx.go",
+			confidence:  1,
+			wantLicense: "MIT",
+			wantType:    Notice,
+		},
+		{
+			desc:        "Go source license header",
+			file:        "testdata/direct/direct.go",
+			confidence:  0.9,
+			wantLicense: "Apache-2.0",
+			wantType:    Notice,
+		},
+		{
+			desc:       "non-existent file",
+			file:       "non-existent-file",
+			confidence: 0.9,
+			wantErr:    true,
+		},
+		{
+			desc:        "empty file path",
+			file:        "",
+			confidence:  0.9,
+			wantLicense: "",
+			wantType:    Unknown,
+		},
+	} {
+		t.Run(test.desc, func(t *testing.T) {
+			c, err := NewLicensecheckClassifier(test.confidence)
+			if err != nil {
+				t.Fatalf("NewLicensecheckClassifier(%v) = (_, %q), want (_, nil)", test.confidence, err)
+			}
+			gotLicense, gotType, err := c.Identify(test.file)
+			if gotErr := err != nil; gotErr != test.wantErr {
+				t.Fatalf("c.Identify(%q) = (_, _, %q), want err? %t", test.file, err, test.wantErr)
+			} else if gotErr {
+				return
+			}
+			if gotLicense != test.wantLicense || gotType != test.wantType {
+				t.Fatalf("c.Identify(%q) = (%q, %q, %v), want (%q, %q, <nil>)", test.file, gotLicense, gotType, err, test.wantLicense, test.wantType)
+			}
+		})
+	}
+}
@@ -0,0 +1,49 @@
+package licenses
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+)
+
+var noticeRegexp = regexp.MustCompile(`^(?i)NOTICES?(\.(txt|md|markdown|rst|html))?$`)
+
+// IsNotice reports whether the file at path is a NOTICE file.
+func IsNotice(path string) bool {
+	return noticeRegexp.MatchString(filepath.Base(path))
+}
+
+// FindNotices returns the paths of all NOTICE files under root, sorted.
+// Nested modules, vendor, testdata and hidden directories are not searched.
+func FindNotices(root string) ([]string, error) {
+	var notices []string
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if path == root {
+				return nil
+			}
+			name := d.Name()
+			if name == "vendor" || name == "testdata" || name[0] == '.' || name[0] == '_' {
+				return filepath.SkipDir
+			}
+			if _, err := os.Stat(filepath.Join(path, "go.mod")); err == nil {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if d.Type().IsRegular() && IsNotice(path) {
+			notices = append(notices, path)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(notices)
+	return notices, nil
+}
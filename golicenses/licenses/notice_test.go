@@ -0,0 +1,46 @@
+package licenses
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestFindNotices(t *testing.T) {
+	root := t.TempDir()
+	for _, path := range []string{
+		"NOTICE",
+		"LICENSE",
+		"notices.go",
+		"sub/NOTICE.txt",
+		"sub/deeper/notice.md",
+		"vendor/x/NOTICE",
+		"testdata/NOTICE",
+		".hidden/NOTICE",
+		"nested/go.mod",
+		"nested/NOTICE",
+	} {
+		path = filepath.Join(root, path)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, nil, 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	got, err := FindNotices(root)
+	if err != nil {
+		t.Fatalf("FindNotices(%q) = (_, %v), want (_, nil)", root, err)
+	}
+	want := []string{
+		filepath.Join(root, "NOTICE"),
+		filepath.Join(root, "sub/NOTICE.txt"),
+		filepath.Join(root, "sub/deeper/notice.md"),
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("FindNotices(%q): diff (-want +got)\n%s", root, diff)
+	}
+}
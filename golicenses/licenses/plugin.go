@@ -0,0 +1,215 @@
+package licenses
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"sync"
+	"time"
+)
+
+// DefaultPluginTimeout is used when a PluginConfig does not specify a timeout.
+const DefaultPluginTimeout = 30 * time.Second
+
+// PluginConfig configures an external classifier executable.
+type PluginConfig struct {
+	// Command is the executable and its arguments.
+	Command []string `mapstructure:"command"`
+	// Timeout limits how long a single classification may take.
+	Timeout time.Duration `mapstructure:"timeout"`
+}
+
+// PluginRequest is written to the plugin's stdin, one JSON object per line.
+type PluginRequest struct {
+	// Path is the path of the license file.
+	Path string `json:"path"`
+	// Contents is the part of the file to classify, i.e. the license section
+	// of a README or the header comment of a Go source file.
+	Contents string `json:"contents"`
+}
+
+// PluginResponse is read from the plugin's stdout, one JSON object per request.
+type PluginResponse struct {
+	// Name is the license name, preferably an SPDX identifier.
+	Name string `json:"name"`
+	// Type is the license type (e.g. "notice"). If empty, it is derived from Name.
+	Type string `json:"type,omitempty"`
+	// Confidence of the match, between 0 and 1.
+	Confidence float64 `json:"confidence"`
+	// Error is set if the plugin could not classify the license.
+	Error string `json:"error,omitempty"`
+}
+
+// pluginClassifier identifies licenses by running an external executable that
+// speaks the plugin protocol: it reads PluginRequests from stdin and answers
+// each with a PluginResponse on stdout. The process is started once and
+// reused for all requests; it is restarted if it exits or times out.
+type pluginClassifier struct {
+	config              PluginConfig
+	confidenceThreshold float64
+
+	mu        sync.Mutex
+	cmd       *exec.Cmd
+	stdin     io.WriteCloser
+	responses chan pluginResult
+}
+
+type pluginResult struct {
+	response PluginResponse
+	err      error
+}
+
+// NewPluginClassifier starts the configured executable and returns a
+// classifier that uses it. Matches with a confidence below
+// confidenceThreshold are reported as unknown licenses.
+// The classifier must be closed to stop the executable.
+func NewPluginClassifier(config PluginConfig, confidenceThreshold float64) (Classifier, error) {
+	if len(config.Command) == 0 {
+		return nil, fmt.Errorf("no classifier plugin command configured")
+	}
+	if config.Timeout <= 0 {
+		config.Timeout = DefaultPluginTimeout
+	}
+	c := &pluginClassifier{config: config, confidenceThreshold: confidenceThreshold}
+	if err := c.start(); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// Identify returns the name and type of a license, given its file path.
+// An empty license path results in an empty name and Unknown type.
+func (c *pluginClassifier) Identify(licensePath string) (string, Type, error) {
+	if licensePath == "" {
+		return "", Unknown, nil
+	}
+	text, spdxID, err := classifiableText(licensePath)
+	if err != nil {
+		return "", "", err
+	}
+	if spdxID != "" {
+		return spdxID, SPDXType(spdxID), nil
+	}
+
+	resp, err := c.request(PluginRequest{Path: licensePath, Contents: text})
+	if err != nil {
+		return "", "", fmt.Errorf("classifier plugin: %w", err)
+	}
+	if resp.Error != "" {
+		return "", "", fmt.Errorf("classifier plugin: %s", resp.Error)
+	}
+	if resp.Name == "" || resp.Confidence < c.confidenceThreshold {
+		return "", "", fmt.Errorf("unknown license")
+	}
+	if resp.Type == "" {
+		return resp.Name, SPDXType(resp.Name), nil
+	}
+	licenseType, err := ParseType(resp.Type)
+	if err != nil {
+		return "", "", fmt.Errorf("classifier plugin: %w", err)
+	}
+	return resp.Name, licenseType, nil
+}
+
+// Close stops the plugin executable. Its stdin is closed first so that it
+// can exit by itself; it is killed if it does not exit in time.
+func (c *pluginClassifier) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.cmd == nil {
+		return nil
+	}
+	_ = c.stdin.Close()
+	select {
+	case <-c.responses:
+	case <-time.After(c.config.Timeout):
+	}
+	return c.stop()
+}
+
+// request sends a single request and waits for its response, restarting the
+// process if needed. The process is killed if it does not answer in time.
+func (c *pluginClassifier) request(req PluginRequest) (PluginResponse, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.cmd == nil {
+		if err := c.start(); err != nil {
+			return PluginResponse{}, err
+		}
+	}
+
+	if err := json.NewEncoder(c.stdin).Encode(req); err != nil {
+		_ = c.stop()
+		return PluginResponse{}, fmt.Errorf("unable to send request: %w", err)
+	}
+	select {
+	case res := <-c.responses:
+		if res.err != nil {
+			_ = c.stop()
+			return PluginResponse{}, res.err
+		}
+		return res.response, nil
+	case <-time.After(c.config.Timeout):
+		_ = c.stop()
+		return PluginResponse{}, fmt.Errorf("no response within %s", c.config.Timeout)
+	}
+}
+
+// start runs the executable and decodes its responses in the background.
+func (c *pluginClassifier) start() error {
+	cmd := exec.Command(c.config.Command[0], c.config.Command[1:]...)
+	cmd.Stderr = os.Stderr
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return err
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return err
+	}
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("unable to start classifier plugin: %w", err)
+	}
+
+	responses := make(chan pluginResult)
+	go func() {
+		dec := json.NewDecoder(stdout)
+		for {
+			var resp PluginResponse
+			if err := dec.Decode(&resp); err != nil {
+				if err == io.EOF {
+					err = fmt.Errorf("plugin exited")
+				} else {
+					err = fmt.Errorf("invalid response: %w", err)
+				}
+				responses <- pluginResult{err: err}
+				close(responses)
+				return
+			}
+			responses <- pluginResult{response: resp}
+		}
+	}()
+
+	c.cmd, c.stdin, c.responses = cmd, stdin, responses
+	return nil
+}
+
+// stop kills the running process, if any, and releases its resources.
+func (c *pluginClassifier) stop() error {
+	if c.cmd == nil {
+		return nil
+	}
+	cmd, responses := c.cmd, c.responses
+	c.cmd, c.stdin, c.responses = nil, nil, nil
+
+	_ = cmd.Process.Kill()
+	// drain the decoder so it can exit
+	go func() {
+		for range responses {
+		}
+	}()
+	_ = cmd.Wait()
+	return nil
+}
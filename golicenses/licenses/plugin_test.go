@@ -0,0 +1,171 @@
+package licenses
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestPluginStub is not a real test: when GOLICENSES_PLUGIN_STUB is set, the
+// test binary acts as the classifier plugin executable used by the tests below.
+func TestPluginStub(t *testing.T) {
+	if os.Getenv("GOLICENSES_PLUGIN_STUB") == "" {
+		return
+	}
+	dec := json.NewDecoder(os.Stdin)
+	enc := json.NewEncoder(os.Stdout)
+	for {
+		var req PluginRequest
+		if err := dec.Decode(&req); err != nil {
+			os.Exit(0)
+		}
+		var resp PluginResponse
+		switch {
+		case strings.Contains(req.Contents, "MIT License"):
+			resp = PluginResponse{Name: "MIT", Confidence: 0.98}
+		case strings.Contains(req.Contents, "ACME"):
+			resp = PluginResponse{Name: "ACME-EULA", Type: "restricted", Confidence: 0.95}
+		case strings.Contains(req.Contents, "vague"):
+			resp = PluginResponse{Name: "MIT", Confidence: 0.2}
+		case strings.Contains(req.Contents, "bogus type"):
+			resp = PluginResponse{Name: "Bogus", Type: "bogus", Confidence: 1}
+		case strings.Contains(req.Contents, "hang"):
+			time.Sleep(time.Minute)
+		case strings.Contains(req.Contents, "crash"):
+			os.Exit(3)
+		default:
+			resp = PluginResponse{Error: "no match for " + filepath.Base(req.Path)}
+		}
+		if err := enc.Encode(resp); err != nil {
+			os.Exit(1)
+		}
+	}
+}
+
+func newStubPlugin(t *testing.T, timeout time.Duration) *pluginClassifier {
+	t.Helper()
+	t.Setenv("GOLICENSES_PLUGIN_STUB", "1")
+	c, err := NewPluginClassifier(PluginConfig{
+		Command: []string{os.Args[0], "-test.run=^TestPluginStub$"},
+		Timeout: timeout,
+	}, 0.9)
+	if err != nil {
+		t.Fatalf("NewPluginClassifier() error: %v", err)
+	}
+	t.Cleanup(func() { _ = c.(*pluginClassifier).Close() })
+	return c.(*pluginClassifier)
+}
+
+func writeLicenseFile(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "LICENSE")
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestPluginIdentify(t *testing.T) {
+	c := newStubPlugin(t, 10*time.Second)
+	pid := c.cmd.Process.Pid
+
+	for _, test := range []struct {
+		desc        string
+		content     string
+		wantLicense string
+		wantType    Type
+		wantErr     string
+	}{
+		{
+			desc:        "type derived from name",
+			content:     "MIT License ...",
+			wantLicense: "MIT",
+			wantType:    Notice,
+		},
+		{
+			desc:        "type from plugin",
+			content:     "ACME Corp. End User License Agreement",
+			wantLicense: "ACME-EULA",
+			wantType:    Restricted,
+		},
+		{
+			desc:    "below confidence threshold",
+			content: "something vague",
+			wantErr: "unknown license",
+		},
+		{
+			desc:    "invalid type",
+			content: "bogus type",
+			wantErr: `unknown license type "bogus"`,
+		},
+		{
+			desc:    "plugin error",
+			content: "no license",
+			wantErr: "no match for LICENSE",
+		},
+	} {
+		t.Run(test.desc, func(t *testing.T) {
+			path := writeLicenseFile(t, test.content)
+			gotLicense, gotType, err := c.Identify(path)
+			if test.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), test.wantErr) {
+					t.Fatalf("c.Identify() error = %v, want %q", err, test.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("c.Identify() error: %v", err)
+			}
+			if gotLicense != test.wantLicense || gotType != test.wantType {
+				t.Fatalf("c.Identify() = (%q, %q), want (%q, %q)", gotLicense, gotType, test.wantLicense, test.wantType)
+			}
+		})
+	}
+
+	if c.cmd == nil || c.cmd.Process.Pid != pid {
+		t.Error("expected the plugin process to be reused for all requests")
+	}
+}
+
+func TestPluginRestart(t *testing.T) {
+	c := newStubPlugin(t, 500*time.Millisecond)
+	mit := writeLicenseFile(t, "MIT License")
+
+	for _, test := range []struct {
+		desc    string
+		content string
+		wantErr string
+	}{
+		{desc: "timeout", content: "hang", wantErr: "no response within 500ms"},
+		{desc: "crash", content: "crash", wantErr: "plugin exited"},
+	} {
+		t.Run(test.desc, func(t *testing.T) {
+			pid := c.cmd.Process.Pid
+			_, _, err := c.Identify(writeLicenseFile(t, test.content))
+			if err == nil || !strings.Contains(err.Error(), test.wantErr) {
+				t.Fatalf("c.Identify() error = %v, want %q", err, test.wantErr)
+			}
+			if c.cmd != nil {
+				t.Fatal("expected the plugin process to be stopped")
+			}
+			if name, _, err := c.Identify(mit); err != nil || name != "MIT" {
+				t.Fatalf("c.Identify() after restart = (%q, _, %v), want MIT", name, err)
+			}
+			if c.cmd.Process.Pid == pid {
+				t.Error("expected a new plugin process")
+			}
+		})
+	}
+}
+
+func TestPluginConfigErrors(t *testing.T) {
+	if _, err := NewPluginClassifier(PluginConfig{}, 0.9); err == nil {
+		t.Error("expected error without a command")
+	}
+	if _, err := NewPluginClassifier(PluginConfig{Command: []string{"./does-not-exist"}}, 0.9); err == nil {
+		t.Error("expected error for a missing executable")
+	}
+}
@@ -0,0 +1,111 @@
+package licenses
+
+import (
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+var (
+	readmeRegexp        = regexp.MustCompile(`^(?i)README.*$`)
+	atxHeadingRegexp    = regexp.MustCompile(`^ {0,3}(#{1,6})\s+(.*?)\s*#*\s*$`)
+	fenceRegexp         = regexp.MustCompile("^ {0,3}(```|~~~)")
+	licenseHeadingRegex = regexp.MustCompile(`^(?i)\W*licen[sc](e|es|ing)\b`)
+)
+
+// IsReadme reports whether the file at path is a README file.
+func IsReadme(path string) bool {
+	return readmeRegexp.MatchString(filepath.Base(path))
+}
+
+type heading struct {
+	// line is the index of the first line of the heading.
+	line int
+	// body is the index of the first line after the heading.
+	body  int
+	level int
+	title string
+}
+
+// ExtractLicenseSection returns the body of the "License" (or "Licence")
+// section of a markdown or reStructuredText README. The section ends at the
+// next heading of the same or a higher level. The second return value is false
+// if the README has no such section.
+func ExtractLicenseSection(content string) (string, bool) {
+	lines := strings.Split(strings.ReplaceAll(content, "\r\n", "\n"), "\n")
+	headings := parseHeadings(lines)
+	for i, h := range headings {
+		if !licenseHeadingRegex.MatchString(h.title) {
+			continue
+		}
+		end := len(lines)
+		for _, next := range headings[i+1:] {
+			if next.level <= h.level {
+				end = next.line
+				break
+			}
+		}
+		section := strings.TrimSpace(strings.Join(lines[h.body:end], "\n"))
+		if section == "" {
+			continue
+		}
+		return section, true
+	}
+	return "", false
+}
+
+// parseHeadings finds ATX ("# Title") and setext/reStructuredText (underlined,
+// optionally overlined) headings, skipping fenced code blocks.
+// Underline styles not used by markdown are ranked in the order they are first
+// seen, which is how reStructuredText assigns section levels.
+func parseHeadings(lines []string) []heading {
+	var headings []heading
+	styles := map[string]int{"=": 1, "-": 2}
+	inFence := false
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+		if fenceRegexp.MatchString(line) {
+			inFence = !inFence
+			continue
+		}
+		if inFence {
+			continue
+		}
+		if m := atxHeadingRegexp.FindStringSubmatch(line); m != nil {
+			headings = append(headings, heading{line: i, body: i + 1, level: len(m[1]), title: m[2]})
+			continue
+		}
+		title := strings.TrimSpace(line)
+		if title == "" || i+1 >= len(lines) || isAdornment(title) {
+			continue
+		}
+		underline := strings.TrimSpace(lines[i+1])
+		if !isAdornment(underline) || len(underline) < min(len(title), 3) {
+			continue
+		}
+		style := underline[:1]
+		start := i
+		if i > 0 && strings.TrimSpace(lines[i-1]) == underline {
+			// overlined reStructuredText title
+			style += style
+			start = i - 1
+		}
+		level, ok := styles[style]
+		if !ok {
+			level = len(styles) + 1
+			styles[style] = level
+		}
+		headings = append(headings, heading{line: start, body: i + 2, level: level, title: title})
+		i++
+	}
+	return headings
+}
+
+// isAdornment reports whether s is a heading underline: a run of a single
+// punctuation character.
+func isAdornment(s string) bool {
+	if s == "" || !strings.ContainsRune("=-~^\"'*+#:._`", rune(s[0])) {
+		return false
+	}
+	return strings.Count(s, s[:1]) == len(s)
+}
@@ -0,0 +1,81 @@
+package licenses
+
+import "testing"
+
+func TestExtractLicenseSection(t *testing.T) {
+	for _, test := range []struct {
+		desc    string
+		content string
+		want    string
+		wantOk  bool
+	}{
+		{
+			desc:    "markdown ATX heading",
+			content: "# Project\n\nAbout.\n\n## License\n\nMIT text\n\n## Contributing\n\nPRs welcome.\n",
+			want:    "MIT text",
+			wantOk:  true,
+		},
+		{
+			desc:    "markdown licence spelling runs to end of file",
+			content: "# Project\n\n## Licence\n\nline one\n\n### Details\n\nline two\n",
+			want:    "line one\n\n### Details\n\nline two",
+			wantOk:  true,
+		},
+		{
+			desc:    "markdown setext heading",
+			content: "Project\n=======\n\nLicense\n-------\n\nMIT text\n\nAuthors\n-------\n",
+			want:    "MIT text",
+			wantOk:  true,
+		},
+		{
+			desc:    "heading inside code block is ignored",
+			content: "# Project\n\n```\n# License\nnot this\n```\n",
+			wantOk:  false,
+		},
+		{
+			desc:    "reStructuredText sections",
+			content: "=======\nProject\n=======\n\nUsage\n~~~~~\n\nrun it\n\nLicense\n~~~~~~~\n\nBSD text\n\nChanges\n~~~~~~~\n",
+			want:    "BSD text",
+			wantOk:  true,
+		},
+		{
+			desc:    "empty license section",
+			content: "# Project\n\n## License\n\n## Other\n",
+			wantOk:  false,
+		},
+		{
+			desc:    "no license section",
+			content: "# Project\n\nJust a readme.\n",
+			wantOk:  false,
+		},
+	} {
+		t.Run(test.desc, func(t *testing.T) {
+			got, ok := ExtractLicenseSection(test.content)
+			if ok != test.wantOk || got != test.want {
+				t.Fatalf("ExtractLicenseSection() = (%q, %t), want (%q, %t)", got, ok, test.want, test.wantOk)
+			}
+		})
+	}
+}
+
+func TestSourceKindOf(t *testing.T) {
+	for _, test := range []struct {
+		path      string
+		want      SourceKind
+		secondary bool
+	}{
+		{path: "", want: UnknownSource},
+		{path: "testdata/LICENSE", want: LicenseFileSource},
+		{path: "testdata/readme/README.md", want: ReadmeSource, secondary: true},
+		{path: "testdata/readme/readme.rst", want: ReadmeSource, secondary: true},
+		{path: "testdata/spdx/spdx.go", want: SourceHeaderSource, secondary: true},
+	} {
+		got := SourceKindOf(test.path)
+		if got != test.want {
+			t.Errorf("SourceKindOf(%q) = %q, want %q", test.path, got, test.want)
+		}
+		if got.IsSecondary() != test.secondary {
+			t.Errorf("%q.IsSecondary() = %v, want %v", got, got.IsSecondary(), test.secondary)
+		}
+	}
+}
@@ -0,0 +1,180 @@
+package licenses
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/google/licenseclassifier"
+)
+
+var (
+	spdxTagRegexp = regexp.MustCompile(`SPDX-License-Identifier:[ \t]*([^\r\n]*)`)
+	spdxOpRegexp  = regexp.MustCompile(`[()]|\s+(?:AND|OR|WITH)\s+`)
+	// spdxExprRegexp matches the syntax of SPDX license expressions: license
+	// IDs (or LicenseRefs) joined by AND, OR and WITH, optionally in parentheses.
+	spdxExprRegexp = regexp.MustCompile(`^\(*[A-Za-z0-9.+:-]+\)*(?:\s+(?:AND|OR|WITH)\s+\(*[A-Za-z0-9.+:-]+\)*)*$`)
+
+	// typeSeverity orders license types from least to most restrictive.
+	typeSeverity = map[Type]int{
+		Unencumbered: 1,
+		Permissive:   2,
+		Notice:       3,
+		Reciprocal:   4,
+		Restricted:   5,
+		Forbidden:    6,
+	}
+)
+
+// IsGoSource reports whether the file at path is a Go source file.
+func IsGoSource(path string) bool {
+	return filepath.Ext(path) == ".go"
+}
+
+// SPDXIdentifiers returns the license expressions of all SPDX-License-Identifier
+// tags in the comments of Go source content, in the order they appear. Tags
+// whose expression is not valid SPDX syntax are skipped.
+func SPDXIdentifiers(content string) []string {
+	f := parseComments(content, false)
+	if f == nil {
+		return nil
+	}
+	var ids []string
+	for _, cg := range f.Comments {
+		for _, c := range cg.List {
+			for _, m := range spdxTagRegexp.FindAllStringSubmatch(c.Text, -1) {
+				id := strings.TrimSpace(m[1])
+				for _, end := range []string{"*/", "-->"} {
+					id = strings.TrimSpace(strings.TrimSuffix(id, end))
+				}
+				if spdxExprRegexp.MatchString(id) {
+					ids = append(ids, id)
+				}
+			}
+		}
+	}
+	return ids
+}
+
+// SPDXType returns the license type of an SPDX license expression.
+// For "AND" expressions the most restrictive type wins, for "OR" expressions
+// the least restrictive one. Expressions mixing both are treated like "AND".
+// Exceptions ("WITH") do not change the type.
+func SPDXType(expr string) Type {
+	if strings.Contains(expr, " OR ") && !strings.Contains(expr, " AND ") {
+		var least Type
+		for _, alt := range strings.Split(expr, " OR ") {
+			t := SPDXType(alt)
+			if t == Unknown {
+				return Unknown
+			}
+			if least == Unknown || typeSeverity[t] < typeSeverity[least] {
+				least = t
+			}
+		}
+		return least
+	}
+	var most Type
+	for _, part := range spdxOpRegexp.Split(expr, -1) {
+		part = strings.TrimSpace(part)
+		if part == "" || strings.Contains(part, "exception") {
+			continue
+		}
+		t := spdxIDType(part)
+		if t == Unknown {
+			return Unknown
+		}
+		if typeSeverity[t] > typeSeverity[most] {
+			most = t
+		}
+	}
+	return most
+}
+
+// spdxIDType looks up the type of a single SPDX license identifier. Suffixes
+// that the license classifier does not use in its names are stripped.
+func spdxIDType(id string) Type {
+	for _, suffix := range []string{"-only", "-or-later", "+"} {
+		id = strings.TrimSuffix(id, suffix)
+	}
+	return Type(licenseclassifier.LicenseType(id))
+}
+
+// ExtractHeaderComment returns the text of the comments that precede the
+// package clause of a Go source file. The second return value is false if
+// there is no such comment.
+func ExtractHeaderComment(content string) (string, bool) {
+	f := parseComments(content, true)
+	if f == nil {
+		return "", false
+	}
+	var header []string
+	for _, cg := range f.Comments {
+		if cg.Pos() >= f.Package {
+			break
+		}
+		if text := strings.TrimSpace(cg.Text()); text != "" {
+			header = append(header, text)
+		}
+	}
+	if len(header) == 0 {
+		return "", false
+	}
+	return strings.Join(header, "\n\n"), true
+}
+
+// parseComments parses the comments of Go source content, only those up to
+// the package clause if headerOnly. It returns nil if the content is not Go
+// source, while the comments before a syntax error are kept.
+func parseComments(content string, headerOnly bool) *ast.File {
+	mode := parser.ParseComments
+	if headerOnly {
+		mode |= parser.PackageClauseOnly
+	}
+	f, err := parser.ParseFile(token.NewFileSet(), "", content, mode)
+	if err != nil && (f == nil || !f.Package.IsValid()) {
+		return nil
+	}
+	return f
+}
+
+// FindInSource returns the path of the first Go source file, in lexical order,
+// that the classifier can identify a license from. This is the fallback for
+// packages without a license file. An empty path is returned if none of the
+// files declares a license.
+func FindInSource(goFiles []string, classifier Classifier) string {
+	files := append([]string(nil), goFiles...)
+	sort.Strings(files)
+	for _, f := range files {
+		if _, _, err := classifier.Identify(f); err == nil {
+			return f
+		}
+	}
+	return ""
+}
+
+// declaredIdentifiers returns the distinct SPDX license expressions declared
+// by the given Go source files, sorted.
+func declaredIdentifiers(goFiles []string) []string {
+	seen := make(map[string]bool)
+	var ids []string
+	for _, f := range goFiles {
+		content, err := os.ReadFile(f)
+		if err != nil {
+			continue
+		}
+		for _, id := range SPDXIdentifiers(string(content)) {
+			if !seen[id] {
+				seen[id] = true
+				ids = append(ids, id)
+			}
+		}
+	}
+	sort.Strings(ids)
+	return ids
+}
@@ -0,0 +1,86 @@
+package licenses
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestSPDXIdentifiers(t *testing.T) {
+	content := "// SPDX-License-Identifier: MIT\n" +
+		"/* SPDX-License-Identifier: Apache-2.0 OR MIT */\n" +
+		"// SPDX-License-Identifier:\n" +
+		"package foo\n"
+	want := []string{"MIT", "Apache-2.0 OR MIT"}
+	if diff := cmp.Diff(want, SPDXIdentifiers(content)); diff != "" {
+		t.Errorf("SPDXIdentifiers(): diff (-want +got)\n%s", diff)
+	}
+}
+
+func TestSPDXIdentifiers_CommentsOnly(t *testing.T) {
+	content := "// SPDX-License-Identifier: (GPL-2.0-only WITH Classpath-exception-2.0) OR LicenseRef-Foo\n" +
+		"package foo\n\n" +
+		"import \"regexp\"\n\n" +
+		"var tag = regexp.MustCompile(`SPDX-License-Identifier:[ \\t]*([^\\r\\n]*)`)\n" +
+		"var fixture = \"// SPDX-License-Identifier: GPL-3.0-only\\n\"\n\n" +
+		"/* SPDX-License-Identifier: see the LICENSE file */\n" +
+		"// SPDX-License-Identifier: MIT -->\n"
+	want := []string{"(GPL-2.0-only WITH Classpath-exception-2.0) OR LicenseRef-Foo", "MIT"}
+	if diff := cmp.Diff(want, SPDXIdentifiers(content)); diff != "" {
+		t.Errorf("SPDXIdentifiers(): diff (-want +got)\n%s", diff)
+	}
+}
+
+func TestSPDXType(t *testing.T) {
+	for _, test := range []struct {
+		expr string
+		want Type
+	}{
+		{expr: "MIT", want: Notice},
+		{expr: "GPL-2.0-only", want: Restricted},
+		{expr: "GPL-2.0+", want: Restricted},
+		{expr: "MIT OR GPL-3.0-or-later", want: Notice},
+		{expr: "MIT AND GPL-3.0-or-later", want: Restricted},
+		{expr: "(MIT AND MPL-2.0)", want: Reciprocal},
+		{expr: "GPL-2.0-only WITH Classpath-exception-2.0", want: Restricted},
+		{expr: "MIT OR LicenseRef-Custom", want: Unknown},
+		{expr: "LicenseRef-Custom", want: Unknown},
+	} {
+		if got := SPDXType(test.expr); got != test.want {
+			t.Errorf("SPDXType(%q) = %q, want %q", test.expr, got, test.want)
+		}
+	}
+}
+
+func TestExtractHeaderComment(t *testing.T) {
+	for _, test := range []struct {
+		desc    string
+		content string
+		want    string
+		wantOk  bool
+	}{
+		{
+			desc:    "header and package doc",
+			content: "// Copyright 2020 Foo\n// Licensed under MIT.\n\n// Package foo does things.\npackage foo\n\n// not a header\nfunc f() {}\n",
+			want:    "Copyright 2020 Foo\nLicensed under MIT.\n\nPackage foo does things.",
+			wantOk:  true,
+		},
+		{
+			desc:    "build constraints only",
+			content: "//go:build linux\n\npackage foo\n",
+			wantOk:  false,
+		},
+		{
+			desc:    "no comments",
+			content: "package foo\n",
+			wantOk:  false,
+		},
+	} {
+		t.Run(test.desc, func(t *testing.T) {
+			got, ok := ExtractHeaderComment(test.content)
+			if ok != test.wantOk || got != test.want {
+				t.Fatalf("ExtractHeaderComment() = (%q, %t), want (%q, %t)", got, ok, test.want, test.wantOk)
+			}
+		})
+	}
+}
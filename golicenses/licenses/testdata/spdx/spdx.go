@@ -0,0 +1,4 @@
+// SPDX-License-Identifier: MIT
+
+// Package spdx has no license file, only SPDX tags in its source files.
+package spdx
@@ -0,0 +1,64 @@
+package golicenses
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Notice is the text of a NOTICE file together with the modules that ship it.
+type Notice struct {
+	Modules []string
+	Text    string
+}
+
+// CollectNotices reads the NOTICE files of the given results. Identical texts
+// are merged into one Notice listing every module that ships it. Notices are
+// sorted by their first module.
+func CollectNotices(results ...LicenseResult) ([]Notice, error) {
+	byText := make(map[string]map[string]bool)
+	for _, res := range results {
+		for _, path := range res.NoticePaths {
+			text, err := readText(path)
+			if err != nil {
+				return nil, fmt.Errorf("unable to read NOTICE file (%s): %w", path, err)
+			}
+			if text == "" {
+				continue
+			}
+			if byText[text] == nil {
+				byText[text] = make(map[string]bool)
+			}
+			byText[text][ModuleLabel(res)] = true
+		}
+	}
+
+	notices := make([]Notice, 0, len(byText))
+	for text, modules := range byText {
+		notice := Notice{Text: text}
+		for m := range modules {
+			notice.Modules = append(notice.Modules, m)
+		}
+		sort.Strings(notice.Modules)
+		notices = append(notices, notice)
+	}
+	sort.Slice(notices, func(i, j int) bool {
+		if notices[i].Modules[0] != notices[j].Modules[0] {
+			return notices[i].Modules[0] < notices[j].Modules[0]
+		}
+		return notices[i].Text < notices[j].Text
+	})
+	return notices, nil
+}
+
+// ModuleLabel names the module of a result as "path@version", falling back to
+// the library name when the module is unknown.
+func ModuleLabel(res LicenseResult) string {
+	switch {
+	case res.Module == "":
+		return res.Library
+	case res.Version == "":
+		return res.Module
+	default:
+		return res.Module + "@" + res.Version
+	}
+}
@@ -0,0 +1,47 @@
+package golicenses
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-test/deep"
+)
+
+func TestCollectNotices(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+	shared := write("NOTICE-shared", "Shared notice\r\n")
+	other := write("NOTICE-other", "\nOther notice\n")
+	empty := write("NOTICE-empty", "  \n")
+
+	results := []LicenseResult{
+		{Library: "github.com/b/b/pkg", Module: "github.com/b/b", Version: "v1.0.0", NoticePaths: []string{shared, empty}},
+		{Library: "github.com/b/b/other", Module: "github.com/b/b", Version: "v1.0.0", NoticePaths: []string{shared}},
+		{Library: "github.com/a/a", Module: "github.com/a/a", Version: "v0.1.0", NoticePaths: []string{shared}},
+		{Library: "example.com/nomodule", NoticePaths: []string{other}},
+		{Library: "github.com/c/c", Module: "github.com/c/c"},
+	}
+
+	notices, err := CollectNotices(results...)
+	if err != nil {
+		t.Fatalf("CollectNotices() error: %v", err)
+	}
+	expected := []Notice{
+		{Modules: []string{"example.com/nomodule"}, Text: "Other notice"},
+		{Modules: []string{"github.com/a/a@v0.1.0", "github.com/b/b@v1.0.0"}, Text: "Shared notice"},
+	}
+	for _, d := range deep.Equal(expected, notices) {
+		t.Errorf("diff: %+v", d)
+	}
+
+	if _, err := CollectNotices(LicenseResult{Library: "x", NoticePaths: []string{filepath.Join(dir, "missing")}}); err == nil {
+		t.Error("expected error for missing NOTICE file, got nil")
+	}
+}
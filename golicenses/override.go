@@ -0,0 +1,80 @@
+package golicenses
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/khulnasoft/go-licenses/golicenses/licenses"
+)
+
+// Override manually asserts the license of modules whose license is not
+// detected, or detected wrongly.
+type Override struct {
+	// Module is a regular expression matched against the whole module path.
+	Module string `mapstructure:"module"`
+	// Version restricts the override to a single module version, if set.
+	Version string `mapstructure:"version"`
+	License string `mapstructure:"license"`
+	// Type is the license type; it is derived from License if empty.
+	Type string `mapstructure:"type"`
+	// Justification explains why the license is asserted, e.g. a link to the
+	// upstream confirmation. It is required.
+	Justification string `mapstructure:"justification"`
+
+	pattern     *regexp.Regexp
+	licenseType licenses.Type
+}
+
+// NewOverrides validates overrides and prepares them for matching.
+func NewOverrides(overrides ...Override) ([]Override, error) {
+	compiled := make([]Override, len(overrides))
+	for idx, o := range overrides {
+		if o.Module == "" || o.License == "" {
+			return nil, fmt.Errorf("override %d: module and license are required", idx+1)
+		}
+		if o.Justification == "" {
+			return nil, fmt.Errorf("override (%s): a justification is required", o.Module)
+		}
+		pattern, err := regexp.Compile("^(?:" + o.Module + ")$")
+		if err != nil {
+			return nil, fmt.Errorf("bad override module pattern (%s): %w", o.Module, err)
+		}
+		o.pattern = pattern
+		if o.Type == "" {
+			o.licenseType = licenses.SPDXType(o.License)
+		} else if o.licenseType, err = licenses.ParseType(o.Type); err != nil {
+			return nil, fmt.Errorf("override (%s): %w", o.Module, err)
+		}
+		compiled[idx] = o
+	}
+	return compiled, nil
+}
+
+// Matches reports whether the override applies to a result. Results without
+// module information are matched by library name.
+func (o Override) Matches(res LicenseResult) bool {
+	module := res.Module
+	if module == "" {
+		module = res.Library
+	}
+	if !o.pattern.MatchString(module) {
+		return false
+	}
+	return o.Version == "" || o.Version == res.Version
+}
+
+// ApplyOverrides replaces the license of a result by the first matching
+// override (see NewOverrides) and marks it as manually asserted.
+func ApplyOverrides(overrides []Override, res LicenseResult) LicenseResult {
+	for _, o := range overrides {
+		if !o.Matches(res) {
+			continue
+		}
+		res.License = o.License
+		res.Type = o.licenseType.String()
+		res.ManuallyAsserted = true
+		res.Justification = o.Justification
+		return res
+	}
+	return res
+}
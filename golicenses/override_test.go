@@ -0,0 +1,86 @@
+package golicenses
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/go-test/deep"
+)
+
+func TestNewOverrides(t *testing.T) {
+	tests := []struct {
+		name     string
+		override Override
+		wantErr  string
+	}{
+		{name: "valid", override: Override{Module: "github.com/foo/.*", License: "MIT", Justification: "ok"}},
+		{name: "no license", override: Override{Module: "github.com/foo/bar", Justification: "ok"}, wantErr: "required"},
+		{name: "no justification", override: Override{Module: "github.com/foo/bar", License: "MIT"}, wantErr: "justification"},
+		{name: "bad pattern", override: Override{Module: "github.com/foo/(", License: "MIT", Justification: "ok"}, wantErr: "bad override module pattern"},
+		{name: "bad type", override: Override{Module: "github.com/foo/bar", License: "MIT", Type: "free", Justification: "ok"}, wantErr: "unknown license type"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := NewOverrides(test.override)
+			if test.wantErr == "" {
+				if err != nil {
+					t.Errorf("NewOverrides() error: %v", err)
+				}
+			} else if err == nil || !strings.Contains(err.Error(), test.wantErr) {
+				t.Errorf("NewOverrides() error = %v, want %q", err, test.wantErr)
+			}
+		})
+	}
+}
+
+func TestApplyOverrides(t *testing.T) {
+	overrides, err := NewOverrides(
+		Override{Module: "github.com/foo/bar", Version: "v1.0.0", License: "Acme-1.0", Type: "restricted", Justification: "vendor contract"},
+		Override{Module: "github.com/foo/.*", License: "MIT", Justification: "confirmed upstream"},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		result   LicenseResult
+		expected LicenseResult
+	}{
+		{
+			name:   "module and version",
+			result: LicenseResult{Library: "github.com/foo/bar/pkg", Module: "github.com/foo/bar", Version: "v1.0.0"},
+			expected: LicenseResult{
+				Library: "github.com/foo/bar/pkg", Module: "github.com/foo/bar", Version: "v1.0.0",
+				License: "Acme-1.0", Type: "restricted", ManuallyAsserted: true, Justification: "vendor contract",
+			},
+		},
+		{
+			name:   "other version falls through, type derived from license",
+			result: LicenseResult{Library: "github.com/foo/bar", Module: "github.com/foo/bar", Version: "v1.1.0", License: "BSD-3-Clause", Type: "notice"},
+			expected: LicenseResult{
+				Library: "github.com/foo/bar", Module: "github.com/foo/bar", Version: "v1.1.0",
+				License: "MIT", Type: "notice", ManuallyAsserted: true, Justification: "confirmed upstream",
+			},
+		},
+		{
+			name:     "pattern matches the whole module path",
+			result:   LicenseResult{Library: "github.com/other/foo/bar", Module: "github.com/other/foo/bar"},
+			expected: LicenseResult{Library: "github.com/other/foo/bar", Module: "github.com/other/foo/bar"},
+		},
+		{
+			name:   "library name without module",
+			result: LicenseResult{Library: "github.com/foo/baz"},
+			expected: LicenseResult{
+				Library: "github.com/foo/baz", License: "MIT", Type: "notice", ManuallyAsserted: true, Justification: "confirmed upstream",
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			for _, d := range deep.Equal(test.expected, ApplyOverrides(overrides, test.result)) {
+				t.Errorf("diff: %+v", d)
+			}
+		})
+	}
+}
@@ -0,0 +1,163 @@
+package attribution
+
+import (
+	"fmt"
+	"html"
+	"io"
+	"strings"
+
+	"github.com/khulnasoft/go-licenses/golicenses"
+)
+
+// Format selects the markup of an attribution document.
+type Format string
+
+// Attribution document formats
+const (
+	Text     = Format("text")
+	Markdown = Format("markdown")
+	HTML     = Format("html")
+)
+
+const title = "Third-Party Software Notices"
+
+const intro = "This document lists the third-party software used by this project, " +
+	"with their copyright statements, NOTICE files and license texts."
+
+// ParseFormat parses a user supplied format name.
+func ParseFormat(userStr string) (Format, error) {
+	switch f := Format(strings.ToLower(userStr)); f {
+	case Text, Markdown, HTML:
+		return f, nil
+	case "md":
+		return Markdown, nil
+	default:
+		return "", fmt.Errorf("unsupported notices format %q (supported: text, markdown, html)", userStr)
+	}
+}
+
+// Presenter writes an attribution document (e.g. THIRD_PARTY_NOTICES).
+type Presenter struct {
+	doc    golicenses.Attribution
+	format Format
+}
+
+// NewPresenter creates a presenter for the given attribution document.
+func NewPresenter(doc golicenses.Attribution, format Format) *Presenter {
+	return &Presenter{doc: doc, format: format}
+}
+
+// Present writes the attribution document to the given writer.
+func (p *Presenter) Present(w io.Writer) error {
+	var b strings.Builder
+	switch p.format {
+	case Text:
+		writeText(&b, p.doc)
+	case Markdown:
+		writeMarkdown(&b, p.doc)
+	case HTML:
+		writeHTML(&b, p.doc)
+	default:
+		return fmt.Errorf("unsupported notices format %q", p.format)
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func licenseName(e golicenses.AttributionEntry) string {
+	switch {
+	case e.License == "":
+		return "Unknown"
+	case e.ManuallyAsserted:
+		return e.License + " (manually asserted)"
+	default:
+		return e.License
+	}
+}
+
+func licenseLine(e golicenses.AttributionEntry) string {
+	if e.LicenseID == "" {
+		return licenseName(e)
+	}
+	return fmt.Sprintf("%s (see license text %q below)", licenseName(e), e.LicenseID)
+}
+
+func writeText(b *strings.Builder, doc golicenses.Attribution) {
+	rule := strings.Repeat("=", 80)
+	fmt.Fprintf(b, "%s\n\n%s\n", strings.ToUpper(title), intro)
+	for _, e := range doc.Entries {
+		fmt.Fprintf(b, "\n%s\n%s\n%s\n\nLicense: %s\n", rule, e.Name, rule, licenseLine(e))
+		if len(e.Copyrights) > 0 {
+			fmt.Fprintf(b, "\n%s\n", strings.Join(e.Copyrights, "\n"))
+		}
+		for _, n := range e.Notices {
+			fmt.Fprintf(b, "\nNOTICE:\n\n%s\n", n)
+		}
+	}
+	if len(doc.Licenses) == 0 {
+		return
+	}
+	fmt.Fprintf(b, "\n%s\nLICENSE TEXTS\n%s\n", rule, rule)
+	for _, l := range doc.Licenses {
+		fmt.Fprintf(b, "\n%s\n%s\nUsed by: %s\n\n%s\n", l.ID, strings.Repeat("-", 80), strings.Join(l.Entries, ", "), l.Text)
+	}
+}
+
+func writeMarkdown(b *strings.Builder, doc golicenses.Attribution) {
+	fmt.Fprintf(b, "# %s\n\n%s\n", title, intro)
+	for _, e := range doc.Entries {
+		fmt.Fprintf(b, "\n## %s\n\n", e.Name)
+		if e.LicenseID != "" {
+			fmt.Fprintf(b, "License: [%s](#%s)\n", licenseName(e), e.LicenseID)
+		} else {
+			fmt.Fprintf(b, "License: %s\n", licenseLine(e))
+		}
+		if len(e.Copyrights) > 0 {
+			fmt.Fprintln(b)
+			for _, c := range e.Copyrights {
+				fmt.Fprintf(b, "- %s\n", c)
+			}
+		}
+		for _, n := range e.Notices {
+			fmt.Fprintf(b, "\nNOTICE:\n\n```\n%s\n```\n", n)
+		}
+	}
+	if len(doc.Licenses) == 0 {
+		return
+	}
+	fmt.Fprintf(b, "\n## License Texts\n")
+	for _, l := range doc.Licenses {
+		fmt.Fprintf(b, "\n### <a id=\"%s\"></a>%s\n\nUsed by: %s\n\n```\n%s\n```\n", l.ID, l.ID, strings.Join(l.Entries, ", "), l.Text)
+	}
+}
+
+func writeHTML(b *strings.Builder, doc golicenses.Attribution) {
+	esc := html.EscapeString
+	fmt.Fprintf(b, "<html><head><title>%s</title></head><body>\n<h1>%s</h1>\n<p>%s</p>\n", title, title, intro)
+	for _, e := range doc.Entries {
+		fmt.Fprintf(b, "<h2>%s</h2>\n", esc(e.Name))
+		if e.LicenseID != "" {
+			fmt.Fprintf(b, "<p>License: <a href=\"#%s\">%s</a></p>\n", esc(e.LicenseID), esc(licenseName(e)))
+		} else {
+			fmt.Fprintf(b, "<p>License: %s</p>\n", esc(licenseLine(e)))
+		}
+		if len(e.Copyrights) > 0 {
+			fmt.Fprint(b, "<ul>\n")
+			for _, c := range e.Copyrights {
+				fmt.Fprintf(b, "<li>%s</li>\n", esc(c))
+			}
+			fmt.Fprint(b, "</ul>\n")
+		}
+		for _, n := range e.Notices {
+			fmt.Fprintf(b, "<p>NOTICE:</p>\n<pre>%s</pre>\n", esc(n))
+		}
+	}
+	if len(doc.Licenses) > 0 {
+		fmt.Fprint(b, "<h2>License Texts</h2>\n")
+		for _, l := range doc.Licenses {
+			fmt.Fprintf(b, "<h3 id=\"%s\">%s</h3>\n<p>Used by: %s</p>\n<pre>%s</pre>\n",
+				esc(l.ID), esc(l.ID), esc(strings.Join(l.Entries, ", ")), esc(l.Text))
+		}
+	}
+	fmt.Fprint(b, "</body></html>\n")
+}
@@ -0,0 +1,68 @@
+package attribution
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/khulnasoft/go-licenses/golicenses"
+	"github.com/stretchr/testify/assert"
+)
+
+var testDoc = golicenses.Attribution{
+	Entries: []golicenses.AttributionEntry{
+		{Name: "example.com/a@v1.0.0", License: "MIT", LicenseID: "MIT", Copyrights: []string{"Copyright 2020 A <a@example.com>"}, Notices: []string{"A notice"}},
+		{Name: "example.com/b@v1.0.0"},
+	},
+	Licenses: []golicenses.LicenseText{
+		{ID: "MIT", License: "MIT", Text: "MIT text", Entries: []string{"example.com/a@v1.0.0"}},
+	},
+}
+
+func TestPresenter_Text(t *testing.T) {
+	var buf bytes.Buffer
+	assert.NoError(t, NewPresenter(testDoc, Text).Present(&buf))
+	out := buf.String()
+	assert.Contains(t, out, "example.com/a@v1.0.0\n")
+	assert.Contains(t, out, "License: MIT (see license text \"MIT\" below)\n")
+	assert.Contains(t, out, "\nCopyright 2020 A <a@example.com>\n")
+	assert.Contains(t, out, "NOTICE:\n\nA notice\n")
+	assert.Contains(t, out, "License: Unknown\n")
+	assert.Contains(t, out, "Used by: example.com/a@v1.0.0\n\nMIT text\n")
+}
+
+func TestPresenter_Markdown(t *testing.T) {
+	var buf bytes.Buffer
+	assert.NoError(t, NewPresenter(testDoc, Markdown).Present(&buf))
+	out := buf.String()
+	assert.Contains(t, out, "## example.com/a@v1.0.0\n\nLicense: [MIT](#MIT)\n")
+	assert.Contains(t, out, "- Copyright 2020 A <a@example.com>\n")
+	assert.Contains(t, out, "### <a id=\"MIT\"></a>MIT\n")
+}
+
+func TestPresenter_HTML(t *testing.T) {
+	var buf bytes.Buffer
+	assert.NoError(t, NewPresenter(testDoc, HTML).Present(&buf))
+	out := buf.String()
+	assert.Contains(t, out, "<p>License: <a href=\"#MIT\">MIT</a></p>")
+	assert.Contains(t, out, "<li>Copyright 2020 A &lt;a@example.com&gt;</li>")
+	assert.Contains(t, out, "<h3 id=\"MIT\">MIT</h3>")
+}
+
+func TestPresenter_Deterministic(t *testing.T) {
+	for _, format := range []Format{Text, Markdown, HTML} {
+		var first, second bytes.Buffer
+		assert.NoError(t, NewPresenter(testDoc, format).Present(&first))
+		assert.NoError(t, NewPresenter(testDoc, format).Present(&second))
+		assert.Equal(t, first.String(), second.String())
+	}
+}
+
+func TestParseFormat(t *testing.T) {
+	for in, want := range map[string]Format{"text": Text, "Markdown": Markdown, "md": Markdown, "HTML": HTML} {
+		got, err := ParseFormat(in)
+		assert.NoError(t, err)
+		assert.Equal(t, want, got)
+	}
+	_, err := ParseFormat("pdf")
+	assert.Error(t, err)
+}
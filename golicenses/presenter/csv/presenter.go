@@ -2,7 +2,9 @@ package csv
 
 import (
 	"encoding/csv"
+	"fmt"
 	"io"
+	"strings"
 
 	"github.com/khulnasoft/go-licenses/golicenses"
 )
@@ -17,13 +19,57 @@ func NewPresenter(results <-chan golicenses.LicenseResult) Presenter {
 	}
 }
 
+// Present writes a line per result with the library, URL, license type and
+// license. Results with a manually asserted license or with findings have a
+// fifth column marking the manual assertion, and those with findings a sixth
+// listing them, so reports without either keep the four column layout.
 func (p Presenter) Present(target io.Writer) error {
 	writer := csv.NewWriter(target)
 	for result := range p.resultStream {
-		if err := writer.Write([]string{result.Library, result.URL, result.Type, result.License}); err != nil {
+		record := []string{result.Library, result.URL, result.Type, result.License}
+		if result.ManuallyAsserted || len(result.Findings) > 0 {
+			var asserted string
+			if result.ManuallyAsserted {
+				asserted = "manually asserted"
+			}
+			record = append(record, asserted)
+		}
+		if len(result.Findings) > 0 {
+			findings := make([]string, len(result.Findings))
+			for i, f := range result.Findings {
+				findings[i] = f.String()
+			}
+			record = append(record, strings.Join(findings, "; "))
+		}
+		if err := writer.Write(record); err != nil {
 			return err
 		}
 	}
 	writer.Flush()
 	return writer.Error()
 }
+
+// ReadResults reads a report written by the CSV presenter.
+func ReadResults(r io.Reader) ([]golicenses.LicenseResult, error) {
+	reader := csv.NewReader(r)
+	// older reports have fewer columns
+	reader.FieldsPerRecord = -1
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	results := make([]golicenses.LicenseResult, 0, len(records))
+	for _, record := range records {
+		if len(record) < 4 {
+			return nil, fmt.Errorf("bad CSV report line %q: expected at least 4 columns", strings.Join(record, ","))
+		}
+		results = append(results, golicenses.LicenseResult{
+			Library:          record[0],
+			URL:              record[1],
+			Type:             record[2],
+			License:          record[3],
+			ManuallyAsserted: len(record) > 4 && record[4] != "",
+		})
+	}
+	return results, nil
+}
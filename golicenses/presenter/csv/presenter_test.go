@@ -0,0 +1,40 @@
+package csv
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/khulnasoft/go-licenses/golicenses"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCSVPresenter(t *testing.T) {
+	input := []golicenses.LicenseResult{
+		{Library: "github.com/foo/bar", URL: "https://github.com/foo/bar/blob/master/LICENSE", Type: "notice", License: "MIT"},
+		{Library: "github.com/foo/baz", Type: "notice", License: "BSD-3-Clause", ManuallyAsserted: true},
+		{Library: "github.com/foo/gpl", Type: "restricted", License: "GPL-2.0", Findings: []golicenses.Finding{
+			{Severity: golicenses.ErrorSeverity, Action: golicenses.DenyAction, Rule: "GPL-.*", Message: "denied by license rule 'GPL-.*'"},
+		}},
+	}
+	results := make(chan golicenses.LicenseResult, len(input))
+	for _, res := range input {
+		results <- res
+	}
+	close(results)
+
+	var buf bytes.Buffer
+	require.NoError(t, NewPresenter(results).Present(&buf))
+	assert.Equal(t, `github.com/foo/bar,https://github.com/foo/bar/blob/master/LICENSE,notice,MIT
+github.com/foo/baz,,notice,BSD-3-Clause,manually asserted
+github.com/foo/gpl,,restricted,GPL-2.0,,error: denied by license rule 'GPL-.*'
+`, buf.String())
+
+	read, err := ReadResults(&buf)
+	require.NoError(t, err)
+	require.Len(t, read, 3)
+	assert.Equal(t, input[0], read[0])
+	assert.True(t, read[1].ManuallyAsserted)
+	assert.False(t, read[2].ManuallyAsserted)
+	assert.Equal(t, "GPL-2.0", read[2].License)
+}
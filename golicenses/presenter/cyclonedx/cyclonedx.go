@@ -0,0 +1,272 @@
+package cyclonedx
+
+import (
+	"io"
+	"net/url"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/licensecheck"
+	"github.com/google/uuid"
+	"github.com/khulnasoft/go-licenses/golicenses"
+	"golang.org/x/mod/module"
+	"golang.org/x/mod/sumdb/dirhash"
+)
+
+const (
+	specVersion = "1.5"
+	toolName    = "golicenses"
+)
+
+// Format is the CycloneDX serialization format.
+type Format int
+
+// CycloneDX formats
+const (
+	JSON Format = iota
+	XML
+)
+
+// Presenter outputs a CycloneDX 1.5 SBOM with a component per module. Each
+// component has a purl, its licenses (as SPDX IDs where possible), the license
+// texts, license file locations and copyrights as evidence, and the hash of
+// the module that go.sum records as the "golang:h1" property. The main module
+// (see newBOM) is the metadata component. The dependency graph is included if the results know the modules
+// they import.
+// https://cyclonedx.org/docs/1.5/json/
+type Presenter struct {
+	results <-chan golicenses.LicenseResult
+	format  Format
+}
+
+// NewPresenter creates a new CycloneDX presenter for the given format.
+func NewPresenter(results <-chan golicenses.LicenseResult, format Format) *Presenter {
+	return &Presenter{results: results, format: format}
+}
+
+// bom is the format independent content of the SBOM.
+type bom struct {
+	serialNumber string
+	timestamp    time.Time
+	main         *component
+	components   []*component
+	// dependencies is nil without dependency data.
+	dependencies []dependency
+}
+
+type component struct {
+	ref        string
+	name       string
+	version    string
+	purl       string
+	licenses   []string
+	evidence   []licenseEvidence
+	copyrights []string
+	properties []property
+
+	dependsOn []string
+}
+
+type licenseEvidence struct {
+	license string
+	// location is the path of the license file, relative to the module.
+	location string
+	text     string
+}
+
+type property struct {
+	name  string
+	value string
+}
+
+type dependency struct {
+	ref       string
+	dependsOn []string
+}
+
+// Present writes the SBOM to the given writer.
+func (p *Presenter) Present(w io.Writer) error {
+	var results []golicenses.LicenseResult
+	for res := range p.results {
+		results = append(results, res)
+	}
+	doc := newBOM(results)
+	if p.format == XML {
+		return writeXML(w, doc)
+	}
+	return writeJSON(w, doc)
+}
+
+// newBOM groups the results by module. The first result of a module without
+// a version is the main module; without module information, it is the first
+// result without a version, like the root package of the finder.
+func newBOM(results []golicenses.LicenseResult) bom {
+	doc := bom{serialNumber: "urn:uuid:" + uuid.NewString(), timestamp: time.Now().UTC()}
+	byName := make(map[string]*component)
+	hasDependencies := false
+	var order []*component
+	var unversioned *component
+	for _, res := range results {
+		name := res.Module
+		if name == "" {
+			name = res.Library
+		}
+		c, ok := byName[name]
+		if !ok {
+			c = &component{name: name, version: res.Version, purl: purl(name, res.Version)}
+			c.ref = c.purl
+			if h1 := moduleHash(res); h1 != "" {
+				c.addProperty("golang:h1", h1)
+			}
+			byName[name] = c
+			order = append(order, c)
+			if doc.main == nil && res.Module != "" && res.Version == "" {
+				doc.main = c
+			}
+			if unversioned == nil && res.Version == "" {
+				unversioned = c
+			}
+		}
+		c.add(res)
+		if len(res.DependsOn) > 0 {
+			hasDependencies = true
+		}
+	}
+	if doc.main == nil {
+		doc.main = unversioned
+	}
+
+	for _, c := range order {
+		if c != doc.main {
+			doc.components = append(doc.components, c)
+		}
+		if !hasDependencies {
+			continue
+		}
+		dep := dependency{ref: c.ref}
+		for _, name := range c.dependsOn {
+			if target, ok := byName[name]; ok && target != c {
+				dep.dependsOn = append(dep.dependsOn, target.ref)
+			}
+		}
+		doc.dependencies = append(doc.dependencies, dep)
+	}
+	return doc
+}
+
+// add merges a library of the component's module into it. License texts that
+// can't be read are left out of the evidence.
+func (c *component) add(res golicenses.LicenseResult) {
+	if res.License != "" {
+		c.licenses = appendNew(c.licenses, res.License)
+	}
+	if res.Path != "" && !c.hasEvidence(res.Path, res.Dir) {
+		if text, err := golicenses.ReadLicenseText(res.Path); err == nil {
+			c.evidence = append(c.evidence, licenseEvidence{license: res.License, location: location(res.Path, res.Dir), text: text})
+		}
+	}
+	for _, copyright := range res.Copyrights {
+		c.copyrights = appendNew(c.copyrights, copyright)
+	}
+	if res.ManuallyAsserted {
+		c.addProperty("golicenses:manually-asserted", "true")
+		c.addProperty("golicenses:justification", res.Justification)
+	}
+	for _, name := range res.DependsOn {
+		c.dependsOn = appendNew(c.dependsOn, name)
+	}
+	sort.Strings(c.dependsOn)
+}
+
+func (c *component) hasEvidence(path, dir string) bool {
+	for _, e := range c.evidence {
+		if e.location == location(path, dir) {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *component) addProperty(name, value string) {
+	for _, p := range c.properties {
+		if p.name == name && p.value == value {
+			return
+		}
+	}
+	c.properties = append(c.properties, property{name: name, value: value})
+}
+
+func appendNew(list []string, s string) []string {
+	for _, item := range list {
+		if item == s {
+			return list
+		}
+	}
+	return append(list, s)
+}
+
+// location returns the path of a license file relative to its module.
+func location(path, dir string) string {
+	if dir != "" {
+		if rel, err := filepath.Rel(dir, path); err == nil && !strings.HasPrefix(rel, "..") {
+			return filepath.ToSlash(rel)
+		}
+	}
+	return filepath.ToSlash(path)
+}
+
+// purl returns the package URL of a Go module, e.g. "pkg:golang/github.com/foo/bar@v1.2.3".
+func purl(path, version string) string {
+	segments := strings.Split(path, "/")
+	for i, s := range segments {
+		segments[i] = purlEscape(s)
+	}
+	p := "pkg:golang/" + strings.Join(segments, "/")
+	if version != "" {
+		p += "@" + purlEscape(version)
+	}
+	return p
+}
+
+// purlEscape percent-encodes a purl component, including "+" which is not
+// escaped in URL paths.
+func purlEscape(s string) string {
+	return strings.ReplaceAll(url.PathEscape(s), "+", "%2B")
+}
+
+// moduleHash returns the hash that go.sum records for the module of a result
+// in the module cache, e.g. "h1:...".
+func moduleHash(res golicenses.LicenseResult) string {
+	if res.Module == "" || res.Version == "" || res.Dir == "" {
+		return ""
+	}
+	// replaced modules are stored under another path
+	escapedPath, err := module.EscapePath(res.Module)
+	if err != nil {
+		return ""
+	}
+	escapedVersion, err := module.EscapeVersion(res.Version)
+	if err != nil || !strings.HasSuffix(filepath.ToSlash(res.Dir), "/"+escapedPath+"@"+escapedVersion) {
+		return ""
+	}
+	h1, err := dirhash.HashDir(res.Dir, res.Module+"@"+res.Version, dirhash.Hash1)
+	if err != nil {
+		return ""
+	}
+	return h1
+}
+
+var spdxIDs = func() map[string]bool {
+	ids := make(map[string]bool)
+	for _, l := range licensecheck.BuiltinLicenses() {
+		ids[l.ID] = true
+	}
+	return ids
+}()
+
+// isSPDXID reports whether a license name is a known SPDX license ID.
+func isSPDXID(license string) bool {
+	return spdxIDs[license]
+}
@@ -0,0 +1,160 @@
+package cyclonedx
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/khulnasoft/go-licenses/golicenses"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testResults(t *testing.T) []golicenses.LicenseResult {
+	t.Helper()
+	appDir := t.TempDir()
+	libDir := filepath.Join(t.TempDir(), "github.com", "foo", "bar@v1.2.3")
+	require.NoError(t, os.MkdirAll(libDir, 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(appDir, "LICENSE"), []byte("Apache License 2.0\n"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(libDir, "LICENSE"), []byte("MIT License\r\n\r\nCopyright (c) 2020 Foo\r\n"), 0o644))
+
+	return []golicenses.LicenseResult{
+		{
+			Library: "example.com/app", Module: "example.com/app", Dir: appDir,
+			Path: filepath.Join(appDir, "LICENSE"), License: "Apache-2.0", Type: "notice",
+			DependsOn: []string{"github.com/foo/bar", "github.com/not/scanned"},
+		},
+		{
+			Library: "github.com/foo/bar/a", Module: "github.com/foo/bar", Version: "v1.2.3", Dir: libDir,
+			Path: filepath.Join(libDir, "LICENSE"), License: "MIT", Type: "notice",
+			Copyrights: []string{"Copyright (c) 2020 Foo"},
+		},
+		{
+			Library: "github.com/foo/bar/b", Module: "github.com/foo/bar", Version: "v1.2.3", Dir: libDir,
+			Path: filepath.Join(libDir, "LICENSE"), License: "MIT", Type: "notice",
+			Copyrights: []string{"Copyright (c) 2020 Foo"},
+			DependsOn:  []string{"example.com/corp"},
+		},
+		{
+			Library: "example.com/corp", Module: "example.com/corp", Version: "v0.1.0+incompatible",
+			License: "ACME-EULA", Type: "restricted",
+			ManuallyAsserted: true, Justification: "Contract 42",
+		},
+	}
+}
+
+func present(t *testing.T, format Format, results []golicenses.LicenseResult) []byte {
+	t.Helper()
+	ch := make(chan golicenses.LicenseResult, len(results))
+	for _, res := range results {
+		ch <- res
+	}
+	close(ch)
+	var buf bytes.Buffer
+	require.NoError(t, NewPresenter(ch, format).Present(&buf))
+	return buf.Bytes()
+}
+
+func TestCycloneDXPresenter_JSON(t *testing.T) {
+	output := present(t, JSON, testResults(t))
+	assert.NotContains(t, string(output), `"hashes"`)
+	var doc jsonBOM
+	require.NoError(t, json.Unmarshal(output, &doc))
+
+	assert.Equal(t, "CycloneDX", doc.BOMFormat)
+	assert.Equal(t, "1.5", doc.SpecVersion)
+	assert.Regexp(t, `^urn:uuid:[0-9a-f-]{36}$`, doc.SerialNumber)
+	require.NotNil(t, doc.Metadata.Component)
+	assert.Equal(t, "application", doc.Metadata.Component.Type)
+	assert.Equal(t, "pkg:golang/example.com/app", doc.Metadata.Component.Purl)
+
+	// libraries of the same module are one component
+	require.Len(t, doc.Components, 2)
+	bar := doc.Components[0]
+	assert.Equal(t, "pkg:golang/github.com/foo/bar@v1.2.3", bar.Purl)
+	assert.Equal(t, bar.Purl, bar.BOMRef)
+	assert.Equal(t, "v1.2.3", bar.Version)
+	assert.Equal(t, []jsonLicenseChoice{{License: jsonLicense{ID: "MIT"}}}, bar.Licenses)
+	require.Len(t, bar.Properties, 1)
+	assert.Equal(t, "golang:h1", bar.Properties[0].Name)
+	assert.Regexp(t, `^h1:[A-Za-z0-9+/]{43}=$`, bar.Properties[0].Value)
+	require.NotNil(t, bar.Evidence)
+	assert.Equal(t, []jsonOccurrence{{Location: "LICENSE"}}, bar.Evidence.Occurrences)
+	require.Len(t, bar.Evidence.Licenses, 1)
+	assert.Equal(t, "MIT License\n\nCopyright (c) 2020 Foo", bar.Evidence.Licenses[0].License.Text.Content)
+	assert.Equal(t, []jsonCopyright{{Text: "Copyright (c) 2020 Foo"}}, bar.Evidence.Copyright)
+
+	corp := doc.Components[1]
+	assert.Equal(t, "pkg:golang/example.com/corp@v0.1.0%2Bincompatible", corp.Purl)
+	assert.Equal(t, []jsonLicenseChoice{{License: jsonLicense{Name: "ACME-EULA"}}}, corp.Licenses)
+	for _, p := range corp.Properties {
+		assert.NotEqual(t, "golang:h1", p.Name)
+	}
+	assert.Contains(t, corp.Properties, jsonProperty{Name: "golicenses:justification", Value: "Contract 42"})
+
+	assert.Equal(t, []jsonDependency{
+		{Ref: "pkg:golang/example.com/app", DependsOn: []string{"pkg:golang/github.com/foo/bar@v1.2.3"}},
+		{Ref: "pkg:golang/github.com/foo/bar@v1.2.3", DependsOn: []string{"pkg:golang/example.com/corp@v0.1.0%2Bincompatible"}},
+		{Ref: "pkg:golang/example.com/corp@v0.1.0%2Bincompatible", DependsOn: []string{}},
+	}, doc.Dependencies)
+}
+
+func TestCycloneDXPresenter_NoDependencyData(t *testing.T) {
+	results := testResults(t)
+	for i := range results {
+		results[i].DependsOn = nil
+	}
+	output := present(t, JSON, results)
+	assert.NotContains(t, string(output), `"dependencies"`)
+	output = present(t, XML, results)
+	assert.NotContains(t, string(output), `<dependencies>`)
+}
+
+func TestCycloneDXPresenter_WithoutModules(t *testing.T) {
+	dir := t.TempDir()
+	results := []golicenses.LicenseResult{
+		{Library: "example.com/app", Dir: dir, License: "Apache-2.0"},
+		{Library: "github.com/foo/bar", Dir: dir, Path: filepath.Join(dir, "missing", "LICENSE"), License: "MIT"},
+	}
+	var doc jsonBOM
+	require.NoError(t, json.Unmarshal(present(t, JSON, results), &doc))
+
+	// the first result without a version is the main module
+	require.NotNil(t, doc.Metadata.Component)
+	assert.Equal(t, "example.com/app", doc.Metadata.Component.Name)
+
+	// licenses whose text can't be read are kept without evidence
+	require.Len(t, doc.Components, 1)
+	assert.Equal(t, []jsonLicenseChoice{{License: jsonLicense{ID: "MIT"}}}, doc.Components[0].Licenses)
+	assert.Nil(t, doc.Components[0].Evidence)
+}
+
+func TestCycloneDXPresenter_XML(t *testing.T) {
+	output := present(t, XML, testResults(t))
+	assert.Contains(t, string(output), `<bom xmlns="http://cyclonedx.org/schema/bom/1.5"`)
+	assert.NotContains(t, string(output), "<hashes>")
+
+	var doc xmlBOM
+	require.NoError(t, xml.Unmarshal(output, &doc))
+	require.NotNil(t, doc.Metadata.Component)
+	assert.Equal(t, "example.com/app", doc.Metadata.Component.Name)
+	require.Len(t, doc.Components, 2)
+	bar := doc.Components[0]
+	assert.Equal(t, "pkg:golang/github.com/foo/bar@v1.2.3", bar.Purl)
+	require.NotNil(t, bar.Licenses)
+	assert.Equal(t, "MIT", bar.Licenses.Licenses[0].ID)
+	require.NotNil(t, bar.Properties)
+	assert.Equal(t, "golang:h1", bar.Properties.Properties[0].Name)
+	require.NotNil(t, bar.Evidence)
+	assert.Equal(t, "MIT License\n\nCopyright (c) 2020 Foo", bar.Evidence.Licenses.Licenses[0].Text.Content)
+	assert.Equal(t, []string{"Copyright (c) 2020 Foo"}, bar.Evidence.Copyright.Texts)
+	assert.Equal(t, "ACME-EULA", doc.Components[1].Licenses.Licenses[0].Name)
+
+	require.NotNil(t, doc.Dependencies)
+	require.Len(t, doc.Dependencies.Dependencies, 3)
+	assert.Equal(t, "pkg:golang/example.com/app", doc.Dependencies.Dependencies[0].Ref)
+	assert.Equal(t, []xmlDependency{{Ref: "pkg:golang/github.com/foo/bar@v1.2.3"}}, doc.Dependencies.Dependencies[0].DependsOn)
+}
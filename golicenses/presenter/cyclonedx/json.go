@@ -0,0 +1,145 @@
+package cyclonedx
+
+import (
+	"encoding/json"
+	"io"
+	"time"
+)
+
+type jsonBOM struct {
+	BOMFormat    string           `json:"bomFormat"`
+	SpecVersion  string           `json:"specVersion"`
+	SerialNumber string           `json:"serialNumber"`
+	Version      int              `json:"version"`
+	Metadata     jsonMetadata     `json:"metadata"`
+	Components   []jsonComponent  `json:"components"`
+	Dependencies []jsonDependency `json:"dependencies,omitempty"`
+}
+
+type jsonMetadata struct {
+	Timestamp string         `json:"timestamp"`
+	Tools     jsonTools      `json:"tools"`
+	Component *jsonComponent `json:"component,omitempty"`
+}
+
+type jsonTools struct {
+	Components []jsonComponent `json:"components"`
+}
+
+type jsonComponent struct {
+	Type       string              `json:"type"`
+	BOMRef     string              `json:"bom-ref,omitempty"`
+	Name       string              `json:"name"`
+	Version    string              `json:"version,omitempty"`
+	Licenses   []jsonLicenseChoice `json:"licenses,omitempty"`
+	Purl       string              `json:"purl,omitempty"`
+	Properties []jsonProperty      `json:"properties,omitempty"`
+	Evidence   *jsonEvidence       `json:"evidence,omitempty"`
+}
+
+type jsonLicenseChoice struct {
+	License jsonLicense `json:"license"`
+}
+
+type jsonLicense struct {
+	ID   string           `json:"id,omitempty"`
+	Name string           `json:"name,omitempty"`
+	Text *jsonLicenseText `json:"text,omitempty"`
+}
+
+type jsonLicenseText struct {
+	ContentType string `json:"contentType"`
+	Content     string `json:"content"`
+}
+
+type jsonProperty struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+type jsonEvidence struct {
+	Occurrences []jsonOccurrence    `json:"occurrences,omitempty"`
+	Licenses    []jsonLicenseChoice `json:"licenses,omitempty"`
+	Copyright   []jsonCopyright     `json:"copyright,omitempty"`
+}
+
+type jsonOccurrence struct {
+	Location string `json:"location"`
+}
+
+type jsonCopyright struct {
+	Text string `json:"text"`
+}
+
+type jsonDependency struct {
+	Ref       string   `json:"ref"`
+	DependsOn []string `json:"dependsOn"`
+}
+
+func jsonLicenseOf(name string) jsonLicense {
+	if isSPDXID(name) {
+		return jsonLicense{ID: name}
+	}
+	return jsonLicense{Name: name}
+}
+
+func (c *component) toJSON(componentType string) jsonComponent {
+	jc := jsonComponent{
+		Type:    componentType,
+		BOMRef:  c.ref,
+		Name:    c.name,
+		Version: c.version,
+		Purl:    c.purl,
+	}
+	for _, l := range c.licenses {
+		jc.Licenses = append(jc.Licenses, jsonLicenseChoice{License: jsonLicenseOf(l)})
+	}
+	for _, p := range c.properties {
+		jc.Properties = append(jc.Properties, jsonProperty{Name: p.name, Value: p.value})
+	}
+	if len(c.evidence) > 0 || len(c.copyrights) > 0 {
+		jc.Evidence = &jsonEvidence{}
+		for _, e := range c.evidence {
+			jc.Evidence.Occurrences = append(jc.Evidence.Occurrences, jsonOccurrence{Location: e.location})
+			if e.license == "" {
+				continue
+			}
+			license := jsonLicenseOf(e.license)
+			license.Text = &jsonLicenseText{ContentType: "text/plain", Content: e.text}
+			jc.Evidence.Licenses = append(jc.Evidence.Licenses, jsonLicenseChoice{License: license})
+		}
+		for _, copyright := range c.copyrights {
+			jc.Evidence.Copyright = append(jc.Evidence.Copyright, jsonCopyright{Text: copyright})
+		}
+	}
+	return jc
+}
+
+func writeJSON(w io.Writer, doc bom) error {
+	out := jsonBOM{
+		BOMFormat:    "CycloneDX",
+		SpecVersion:  specVersion,
+		SerialNumber: doc.serialNumber,
+		Version:      1,
+		Metadata: jsonMetadata{
+			Timestamp: doc.timestamp.Format(time.RFC3339),
+			Tools:     jsonTools{Components: []jsonComponent{{Type: "application", Name: toolName}}},
+		},
+		Components: make([]jsonComponent, 0, len(doc.components)),
+	}
+	if doc.main != nil {
+		main := doc.main.toJSON("application")
+		out.Metadata.Component = &main
+	}
+	for _, c := range doc.components {
+		out.Components = append(out.Components, c.toJSON("library"))
+	}
+	for _, d := range doc.dependencies {
+		out.Dependencies = append(out.Dependencies, jsonDependency{Ref: d.ref, DependsOn: append([]string{}, d.dependsOn...)})
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	return enc.Encode(out)
+}
@@ -0,0 +1,182 @@
+package cyclonedx
+
+import (
+	"encoding/xml"
+	"io"
+	"time"
+)
+
+const xmlNamespace = "http://cyclonedx.org/schema/bom/1.5"
+
+type xmlBOM struct {
+	XMLName      xml.Name         `xml:"bom"`
+	Namespace    string           `xml:"xmlns,attr"`
+	SerialNumber string           `xml:"serialNumber,attr"`
+	Version      int              `xml:"version,attr"`
+	Metadata     xmlMetadata      `xml:"metadata"`
+	Components   []xmlComponent   `xml:"components>component"`
+	Dependencies *xmlDependencies `xml:"dependencies,omitempty"`
+}
+
+type xmlMetadata struct {
+	Timestamp string         `xml:"timestamp"`
+	Tools     []xmlComponent `xml:"tools>components>component"`
+	Component *xmlComponent  `xml:"component,omitempty"`
+}
+
+// xmlComponent elements are ordered as required by the schema.
+type xmlComponent struct {
+	Type       string         `xml:"type,attr"`
+	BOMRef     string         `xml:"bom-ref,attr,omitempty"`
+	Name       string         `xml:"name"`
+	Version    string         `xml:"version,omitempty"`
+	Licenses   *xmlLicenses   `xml:"licenses,omitempty"`
+	Purl       string         `xml:"purl,omitempty"`
+	Properties *xmlProperties `xml:"properties,omitempty"`
+	Evidence   *xmlEvidence   `xml:"evidence,omitempty"`
+}
+
+// Wrapper elements are pointers, so that empty ones are omitted.
+
+type xmlLicenses struct {
+	Licenses []xmlLicense `xml:"license"`
+}
+
+type xmlProperties struct {
+	Properties []xmlProperty `xml:"property"`
+}
+
+type xmlOccurrences struct {
+	Occurrences []xmlOccurrence `xml:"occurrence"`
+}
+
+type xmlCopyright struct {
+	Texts []string `xml:"text"`
+}
+
+type xmlLicense struct {
+	ID   string          `xml:"id,omitempty"`
+	Name string          `xml:"name,omitempty"`
+	Text *xmlLicenseText `xml:"text,omitempty"`
+}
+
+type xmlLicenseText struct {
+	ContentType string `xml:"content-type,attr"`
+	Content     string `xml:",cdata"`
+}
+
+type xmlProperty struct {
+	Name  string `xml:"name,attr"`
+	Value string `xml:",chardata"`
+}
+
+type xmlEvidence struct {
+	Occurrences *xmlOccurrences `xml:"occurrences,omitempty"`
+	Licenses    *xmlLicenses    `xml:"licenses,omitempty"`
+	Copyright   *xmlCopyright   `xml:"copyright,omitempty"`
+}
+
+type xmlOccurrence struct {
+	Location string `xml:"location"`
+}
+
+type xmlDependencies struct {
+	Dependencies []xmlDependency `xml:"dependency"`
+}
+
+type xmlDependency struct {
+	Ref       string          `xml:"ref,attr"`
+	DependsOn []xmlDependency `xml:"dependency,omitempty"`
+}
+
+func xmlLicenseOf(name string) xmlLicense {
+	if isSPDXID(name) {
+		return xmlLicense{ID: name}
+	}
+	return xmlLicense{Name: name}
+}
+
+func (c *component) toXML(componentType string) xmlComponent {
+	xc := xmlComponent{
+		Type:    componentType,
+		BOMRef:  c.ref,
+		Name:    c.name,
+		Version: c.version,
+		Purl:    c.purl,
+	}
+	if len(c.licenses) > 0 {
+		xc.Licenses = &xmlLicenses{}
+		for _, l := range c.licenses {
+			xc.Licenses.Licenses = append(xc.Licenses.Licenses, xmlLicenseOf(l))
+		}
+	}
+	if len(c.properties) > 0 {
+		xc.Properties = &xmlProperties{}
+		for _, p := range c.properties {
+			xc.Properties.Properties = append(xc.Properties.Properties, xmlProperty{Name: p.name, Value: p.value})
+		}
+	}
+	if len(c.evidence) == 0 && len(c.copyrights) == 0 {
+		return xc
+	}
+	xc.Evidence = &xmlEvidence{}
+	if len(c.evidence) > 0 {
+		xc.Evidence.Occurrences = &xmlOccurrences{}
+	}
+	for _, e := range c.evidence {
+		xc.Evidence.Occurrences.Occurrences = append(xc.Evidence.Occurrences.Occurrences, xmlOccurrence{Location: e.location})
+		if e.license == "" {
+			continue
+		}
+		if xc.Evidence.Licenses == nil {
+			xc.Evidence.Licenses = &xmlLicenses{}
+		}
+		license := xmlLicenseOf(e.license)
+		license.Text = &xmlLicenseText{ContentType: "text/plain", Content: e.text}
+		xc.Evidence.Licenses.Licenses = append(xc.Evidence.Licenses.Licenses, license)
+	}
+	if len(c.copyrights) > 0 {
+		xc.Evidence.Copyright = &xmlCopyright{Texts: c.copyrights}
+	}
+	return xc
+}
+
+func writeXML(w io.Writer, doc bom) error {
+	out := xmlBOM{
+		Namespace:    xmlNamespace,
+		SerialNumber: doc.serialNumber,
+		Version:      1,
+		Metadata: xmlMetadata{
+			Timestamp: doc.timestamp.Format(time.RFC3339),
+			Tools:     []xmlComponent{{Type: "application", Name: toolName}},
+		},
+	}
+	if doc.main != nil {
+		main := doc.main.toXML("application")
+		out.Metadata.Component = &main
+	}
+	for _, c := range doc.components {
+		out.Components = append(out.Components, c.toXML("library"))
+	}
+	if doc.dependencies != nil {
+		out.Dependencies = &xmlDependencies{}
+		for _, d := range doc.dependencies {
+			dep := xmlDependency{Ref: d.ref}
+			for _, ref := range d.dependsOn {
+				dep.DependsOn = append(dep.DependsOn, xmlDependency{Ref: ref})
+			}
+			out.Dependencies.Dependencies = append(out.Dependencies.Dependencies, dep)
+		}
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(out); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
@@ -1,12 +1,14 @@
 package html
 
-// LicenseResult fields: Library, URL, Path, License, Type, Errs
+// LicenseResult fields: Library, URL, Path, License, Type, ManuallyAsserted, Justification, Findings, Errs
 // Example: Library (package name), License (license type)
 import (
 	"fmt"
+	"html"
 	"io"
 
 	"github.com/khulnasoft/go-licenses/golicenses"
+	"github.com/khulnasoft/go-licenses/golicenses/licenses"
 )
 
 type Presenter struct {
@@ -20,8 +22,36 @@ func NewPresenter(results <-chan golicenses.LicenseResult) *Presenter {
 func (p *Presenter) Present(w io.Writer) error {
 	fmt.Fprintf(w, "<html><head><title>License Report</title></head><body><h1>License Report</h1><ul>")
 	for res := range p.results {
-		fmt.Fprintf(w, "<li><strong>%s</strong>: <code>%s</code></li>", res.Library, res.License)
+		fmt.Fprintf(w, "<li><strong>%s</strong>: <code>%s</code>%s", res.Library, res.License, note(res))
+		if len(res.Copyrights) > 0 {
+			fmt.Fprint(w, "<ul>")
+			for _, c := range res.Copyrights {
+				fmt.Fprintf(w, "<li>%s</li>", html.EscapeString(c))
+			}
+			fmt.Fprint(w, "</ul>")
+		}
+		if len(res.Findings) > 0 {
+			fmt.Fprint(w, "<ul>")
+			for _, f := range res.Findings {
+				fmt.Fprintf(w, "<li><strong>%s</strong>: %s</li>", f.Severity, html.EscapeString(f.Message))
+			}
+			fmt.Fprint(w, "</ul>")
+		}
+		fmt.Fprint(w, "</li>")
 	}
 	fmt.Fprint(w, "</ul></body></html>")
 	return nil
 }
+
+// note flags licenses that were manually asserted or did not come from a
+// dedicated license file.
+func note(res golicenses.LicenseResult) string {
+	switch {
+	case res.ManuallyAsserted:
+		return fmt.Sprintf(" <em>(manually asserted: %s)</em>", html.EscapeString(res.Justification))
+	case !licenses.SourceKind(res.Source).IsSecondary():
+		return ""
+	default:
+		return fmt.Sprintf(" <em>(source: %s)</em>", res.Source)
+	}
+}
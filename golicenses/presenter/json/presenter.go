@@ -3,18 +3,37 @@ package json
 import (
 	"encoding/json"
 	"io"
+	"path/filepath"
+	"strings"
 
-	"github.com/hashicorp/go-multierror"
 	"github.com/khulnasoft/go-licenses/golicenses"
 )
 
 type jsonResult struct {
-	Pkg string `json:"package"`
-	URL string `json:"url"`
+	Pkg     string `json:"package"`
+	Module  string `json:"module,omitempty"`
+	Version string `json:"version,omitempty"`
+	URL     string `json:"url"`
 	// Path     string   `json:"local-path"`
-	Name     string   `json:"name"`
-	Type     string   `json:"type"`
-	Warnings []string `json:"warnings,omitempty"`
+	Name       string   `json:"name"`
+	Type       string   `json:"type"`
+	Source     string   `json:"source,omitempty"`
+	Copyrights []string `json:"copyrights,omitempty"`
+	// Notices are the NOTICE files, relative to the module directory.
+	Notices []string `json:"notices,omitempty"`
+	// ManuallyAsserted is set if the license was set by an override.
+	ManuallyAsserted bool     `json:"manually-asserted,omitempty"`
+	Justification    string   `json:"justification,omitempty"`
+	Warnings         []string `json:"warnings,omitempty"`
+	// Findings are the rule violations of the package.
+	Findings []jsonFinding `json:"findings,omitempty"`
+}
+
+type jsonFinding struct {
+	Severity string `json:"severity"`
+	Action   string `json:"action"`
+	Rule     string `json:"rule"`
+	Message  string `json:"message"`
 }
 
 type Presenter struct {
@@ -27,25 +46,6 @@ func NewPresenter(results <-chan golicenses.LicenseResult) Presenter {
 	}
 }
 
-func unwrap(err error) []error {
-	errs := make([]error, 0)
-	if err == nil {
-		return errs
-	}
-
-	if mErr, ok := err.(*multierror.Error); ok {
-		if mErr == nil {
-			return errs
-		}
-		for _, err := range mErr.Errors {
-			errs = append(errs, unwrap(err)...)
-		}
-	} else {
-		errs = append(errs, err)
-	}
-	return errs
-}
-
 func (p Presenter) Present(target io.Writer) error {
 	writer := json.NewEncoder(target)
 	writer.SetEscapeHTML(false)
@@ -54,20 +54,77 @@ func (p Presenter) Present(target io.Writer) error {
 	results := make([]jsonResult, 0)
 	for result := range p.resultStream {
 		warnings := make([]string, 0)
-		if result.Errs != nil {
-			for _, err := range unwrap(result.Errs) {
-				warnings = append(warnings, err.Error())
-			}
+		for _, err := range result.Errors() {
+			warnings = append(warnings, err.Error())
+		}
+		var findings []jsonFinding
+		for _, f := range result.Findings {
+			findings = append(findings, jsonFinding{
+				Severity: f.Severity.String(),
+				Action:   strings.ToLower(f.Action.String()),
+				Rule:     f.Rule,
+				Message:  f.Message,
+			})
 		}
 		results = append(results, jsonResult{
-			Pkg:  result.Library,
-			URL:  result.URL,
-			Name: result.License,
-			Type: result.Type,
+			Pkg:        result.Library,
+			Module:     result.Module,
+			Version:    result.Version,
+			URL:        result.URL,
+			Name:       result.License,
+			Type:       result.Type,
+			Source:     result.Source,
+			Copyrights: result.Copyrights,
+			Notices:    noticePaths(result),
 			//Path:     result.Path,
-			Warnings: warnings,
+			ManuallyAsserted: result.ManuallyAsserted,
+			Justification:    result.Justification,
+			Warnings:         warnings,
+			Findings:         findings,
 		})
 	}
 
 	return writer.Encode(&results)
 }
+
+// noticePaths returns the NOTICE files of a result relative to its module
+// directory, so that reports do not contain local paths.
+func noticePaths(result golicenses.LicenseResult) []string {
+	var paths []string
+	for _, path := range result.NoticePaths {
+		rel := filepath.Base(path)
+		if result.Dir != "" {
+			if r, err := filepath.Rel(result.Dir, path); err == nil && r != ".." && !strings.HasPrefix(r, ".."+string(filepath.Separator)) {
+				rel = r
+			}
+		}
+		paths = append(paths, filepath.ToSlash(rel))
+	}
+	return paths
+}
+
+// ReadResults reads a report written by the JSON presenter. Findings and
+// warnings are not read back.
+func ReadResults(r io.Reader) ([]golicenses.LicenseResult, error) {
+	var report []jsonResult
+	if err := json.NewDecoder(r).Decode(&report); err != nil {
+		return nil, err
+	}
+	results := make([]golicenses.LicenseResult, len(report))
+	for i, res := range report {
+		results[i] = golicenses.LicenseResult{
+			Library:          res.Pkg,
+			Module:           res.Module,
+			Version:          res.Version,
+			URL:              res.URL,
+			License:          res.Name,
+			Type:             res.Type,
+			Source:           res.Source,
+			Copyrights:       res.Copyrights,
+			NoticePaths:      res.Notices,
+			ManuallyAsserted: res.ManuallyAsserted,
+			Justification:    res.Justification,
+		}
+	}
+	return results, nil
+}
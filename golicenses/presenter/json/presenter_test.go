@@ -0,0 +1,31 @@
+package json
+
+import (
+	"bytes"
+	"path/filepath"
+	"testing"
+
+	"github.com/khulnasoft/go-licenses/golicenses"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJSONPresenter_NoticePaths(t *testing.T) {
+	dir := filepath.Join(string(filepath.Separator), "home", "user", "go", "pkg", "mod", "github.com", "foo", "bar@v1.0.0")
+	results := make(chan golicenses.LicenseResult, 1)
+	results <- golicenses.LicenseResult{
+		Library:     "github.com/foo/bar",
+		Dir:         dir,
+		License:     "Apache-2.0",
+		NoticePaths: []string{filepath.Join(dir, "NOTICE"), filepath.Join(dir, "third_party", "NOTICE.txt")},
+	}
+	close(results)
+
+	var buf bytes.Buffer
+	require.NoError(t, NewPresenter(results).Present(&buf))
+	assert.Contains(t, buf.String(), `"notices": [
+      "NOTICE",
+      "third_party/NOTICE.txt"
+    ]`)
+	assert.NotContains(t, buf.String(), "/home/user")
+}
@@ -0,0 +1,151 @@
+package junit
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/khulnasoft/go-licenses/golicenses"
+)
+
+// Failure types
+const (
+	// ViolationFailure is a finding of warn or error severity.
+	ViolationFailure = "policy-violation"
+	// UnknownLicenseFailure is a library whose license was not identified.
+	UnknownLicenseFailure = "unknown-license"
+	// ClassificationFailure is an unknown license due to an error finding or
+	// identifying it.
+	ClassificationFailure = "classification-error"
+)
+
+// Presenter outputs a JUnit XML report with a test case per library. Findings
+// of warn or error severity (see golicenses.AnnotateResults) and unknown
+// licenses fail the test case, along with the errors that kept the license
+// from being identified. Info findings and other errors, like a missing
+// license URL, are reported as its output.
+type Presenter struct {
+	results <-chan golicenses.LicenseResult
+}
+
+// NewPresenter creates a new JUnit presenter.
+func NewPresenter(results <-chan golicenses.LicenseResult) *Presenter {
+	return &Presenter{results: results}
+}
+
+type testSuites struct {
+	XMLName  xml.Name    `xml:"testsuites"`
+	Name     string      `xml:"name,attr"`
+	Tests    int         `xml:"tests,attr"`
+	Failures int         `xml:"failures,attr"`
+	Suites   []testSuite `xml:"testsuite"`
+}
+
+type testSuite struct {
+	Name     string     `xml:"name,attr"`
+	Tests    int        `xml:"tests,attr"`
+	Failures int        `xml:"failures,attr"`
+	Errors   int        `xml:"errors,attr"`
+	Cases    []testCase `xml:"testcase"`
+}
+
+type testCase struct {
+	ClassName string   `xml:"classname,attr"`
+	Name      string   `xml:"name,attr"`
+	Failure   *failure `xml:"failure,omitempty"`
+	SystemOut *output  `xml:"system-out,omitempty"`
+}
+
+type output struct {
+	Text string `xml:",cdata"`
+}
+
+type failure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Details string `xml:",cdata"`
+}
+
+// problem is a reason for a test case to fail.
+type problem struct {
+	kind    string
+	message string
+}
+
+// Present writes the JUnit report to the given writer.
+func (p *Presenter) Present(w io.Writer) error {
+	suite := testSuite{Name: "licenses", Cases: make([]testCase, 0)}
+	for res := range p.results {
+		tc := testCase{ClassName: res.Module, Name: res.Library}
+		if tc.ClassName == "" {
+			tc.ClassName = res.Library
+		}
+		lines := []string{fmt.Sprintf("license: %s (%s)", orUnknown(res.License), orUnknown(res.Type))}
+		if res.Version != "" {
+			lines = append(lines, "version: "+res.Version)
+		}
+		if res.ManuallyAsserted {
+			lines = append(lines, "manually asserted: "+res.Justification)
+		}
+
+		var problems []problem
+		for _, f := range res.Findings {
+			if f.Severity < golicenses.WarnSeverity {
+				lines = append(lines, f.String())
+				continue
+			}
+			problems = append(problems, problem{kind: ViolationFailure, message: f.String()})
+		}
+		errs := res.Errors()
+		if res.License == "" {
+			kind := UnknownLicenseFailure
+			if len(errs) > 0 {
+				kind = ClassificationFailure
+			}
+			problems = append(problems, problem{kind: kind, message: "no license identified"})
+			for _, err := range errs {
+				problems = append(problems, problem{kind: kind, message: err.Error()})
+			}
+		} else {
+			for _, err := range errs {
+				lines = append(lines, "warning: "+err.Error())
+			}
+		}
+
+		if len(problems) > 0 {
+			details := make([]string, len(problems))
+			for idx, pr := range problems {
+				details[idx] = pr.message
+			}
+			tc.Failure = &failure{
+				Message: problems[0].message,
+				Type:    problems[0].kind,
+				Details: strings.Join(details, "\n"),
+			}
+			suite.Failures++
+		}
+		tc.SystemOut = &output{Text: strings.Join(lines, "\n")}
+		suite.Cases = append(suite.Cases, tc)
+		suite.Tests++
+	}
+
+	doc := testSuites{Name: "golicenses", Tests: suite.Tests, Failures: suite.Failures, Suites: []testSuite{suite}}
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(doc); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+func orUnknown(s string) string {
+	if s == "" {
+		return "unknown"
+	}
+	return s
+}
@@ -0,0 +1,66 @@
+package junit
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"testing"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/khulnasoft/go-licenses/golicenses"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJUnitPresenter_Present(t *testing.T) {
+	results := make(chan golicenses.LicenseResult)
+	go func() {
+		defer close(results)
+		results <- golicenses.LicenseResult{
+			Library: "github.com/ok/lib", Module: "github.com/ok/lib", Version: "v1.0.0", License: "MIT", Type: "notice",
+			Findings: []golicenses.Finding{{Severity: golicenses.InfoSeverity, Action: golicenses.AllowAction, Rule: "license rule 'MIT'", Message: "license MIT is allowed by license rule 'MIT'"}},
+		}
+		results <- golicenses.LicenseResult{
+			Library: "github.com/gpl/lib/pkg", Module: "github.com/gpl/lib", Version: "v2.0.0", License: "GPL-3.0", Type: "restricted",
+			Findings: []golicenses.Finding{{Severity: golicenses.ErrorSeverity, Action: golicenses.DenyAction, Rule: "license rule 'GPL-.*'", Message: "license GPL-3.0 is forbidden by license rule 'GPL-.*'"}},
+		}
+		results <- golicenses.LicenseResult{
+			Library: "github.com/url/lib", License: "MIT", Type: "notice",
+			Errs: multierror.Append(nil, errors.New("failed to locate license URL (LICENSE): unsupported host")),
+		}
+		results <- golicenses.LicenseResult{
+			Library: "github.com/unknown/lib",
+			Errs:    multierror.Append(nil, errors.New("failed to identify license (LICENSE): no match")),
+		}
+	}()
+
+	var buf bytes.Buffer
+	require.NoError(t, NewPresenter(results).Present(&buf))
+	assert.Contains(t, buf.String(), xml.Header)
+
+	var doc testSuites
+	require.NoError(t, xml.Unmarshal(buf.Bytes(), &doc))
+	assert.Equal(t, 4, doc.Tests)
+	assert.Equal(t, 2, doc.Failures)
+	require.Len(t, doc.Suites, 1)
+	cases := doc.Suites[0].Cases
+	require.Len(t, cases, 4)
+
+	assert.Equal(t, "github.com/ok/lib", cases[0].ClassName)
+	assert.Nil(t, cases[0].Failure)
+	assert.Equal(t, "license: MIT (notice)\nversion: v1.0.0\ninfo: license MIT is allowed by license rule 'MIT'", cases[0].SystemOut.Text)
+
+	assert.Equal(t, "github.com/gpl/lib", cases[1].ClassName)
+	assert.Equal(t, "github.com/gpl/lib/pkg", cases[1].Name)
+	require.NotNil(t, cases[1].Failure)
+	assert.Equal(t, ViolationFailure, cases[1].Failure.Type)
+	assert.Equal(t, "error: license GPL-3.0 is forbidden by license rule 'GPL-.*'", cases[1].Failure.Message)
+
+	// errors that did not keep the license from being identified do not fail
+	assert.Nil(t, cases[2].Failure)
+	assert.Equal(t, "license: MIT (notice)\nwarning: failed to locate license URL (LICENSE): unsupported host", cases[2].SystemOut.Text)
+
+	require.NotNil(t, cases[3].Failure)
+	assert.Equal(t, ClassificationFailure, cases[3].Failure.Type)
+	assert.Equal(t, "no license identified\nfailed to identify license (LICENSE): no match", cases[3].Failure.Details)
+}
@@ -5,6 +5,7 @@ import (
 	"io"
 
 	"github.com/khulnasoft/go-licenses/golicenses"
+	"github.com/khulnasoft/go-licenses/golicenses/licenses"
 )
 
 type Presenter struct {
@@ -18,7 +19,26 @@ func NewPresenter(results <-chan golicenses.LicenseResult) *Presenter {
 func (p *Presenter) Present(w io.Writer) error {
 	fmt.Fprintf(w, "# License Report\n\n")
 	for res := range p.results {
-		fmt.Fprintf(w, "- **%s**: `%s`\n", res.Library, res.License)
+		fmt.Fprintf(w, "- **%s**: `%s`%s\n", res.Library, res.License, note(res))
+		for _, c := range res.Copyrights {
+			fmt.Fprintf(w, "  - %s\n", c)
+		}
+		for _, f := range res.Findings {
+			fmt.Fprintf(w, "  - **%s**: %s\n", f.Severity, f.Message)
+		}
 	}
 	return nil
 }
+
+// note flags licenses that were manually asserted or did not come from a
+// dedicated license file.
+func note(res golicenses.LicenseResult) string {
+	switch {
+	case res.ManuallyAsserted:
+		return fmt.Sprintf(" _(manually asserted: %s)_", res.Justification)
+	case !licenses.SourceKind(res.Source).IsSecondary():
+		return ""
+	default:
+		return fmt.Sprintf(" _(source: %s)_", res.Source)
+	}
+}
@@ -17,11 +17,12 @@ func TestMarkdownPresenter_Present(t *testing.T) {
 	go func() {
 		defer close(results)
 		results <- golicenses.LicenseResult{
-			Library: "library1",
-			URL:     "http://example.com/library1",
-			Path:    "/path/to/library1",
-			License: "MIT",
-			Type:    "Permissive",
+			Library:    "library1",
+			URL:        "http://example.com/library1",
+			Path:       "/path/to/library1",
+			License:    "MIT",
+			Type:       "Permissive",
+			Copyrights: []string{"Copyright (c) 2019 Jane Doe"},
 		}
 		results <- golicenses.LicenseResult{
 			Library: "library2",
@@ -31,6 +32,25 @@ func TestMarkdownPresenter_Present(t *testing.T) {
 			Type:    "Permissive",
 			Errs:    nil, // Explicitly nil for clarity
 		}
+		results <- golicenses.LicenseResult{
+			Library: "library3",
+			License: "BSD-3-Clause",
+			Source:  "readme",
+		}
+		results <- golicenses.LicenseResult{
+			Library:          "library4",
+			License:          "MIT",
+			Source:           "readme",
+			ManuallyAsserted: true,
+			Justification:    "confirmed upstream",
+		}
+		results <- golicenses.LicenseResult{
+			Library: "library5",
+			License: "MPL-2.0",
+			Findings: []golicenses.Finding{
+				{Severity: golicenses.WarnSeverity, Action: golicenses.ReviewAction, Message: "license MPL-2.0 needs review by license rule 'MPL-.*'"},
+			},
+		}
 	}()
 
 	err := p.Present(&outputBuffer)
@@ -38,7 +58,12 @@ func TestMarkdownPresenter_Present(t *testing.T) {
 
 	expectedOutput := "# License Report\n\n" +
 		"- **library1**: `MIT`\n" +
-		"- **library2**: `Apache-2.0`\n"
+		"  - Copyright (c) 2019 Jane Doe\n" +
+		"- **library2**: `Apache-2.0`\n" +
+		"- **library3**: `BSD-3-Clause` _(source: readme)_\n" +
+		"- **library4**: `MIT` _(manually asserted: confirmed upstream)_\n" +
+		"- **library5**: `MPL-2.0`\n" +
+		"  - **warn**: license MPL-2.0 needs review by license rule 'MPL-.*'\n"
 
 	assert.Equal(t, expectedOutput, outputBuffer.String(), "Output should match expected Markdown format")
 }
@@ -11,6 +11,10 @@ const (
 	HTMLPresenter
 	SPDXPresenter     // Added for SPDX output
 	TemplatePresenter // Added for template-based output
+	SARIFPresenter
+	JUnitPresenter
+	CycloneDXJSONPresenter
+	CycloneDXXMLPresenter
 )
 
 var optionStr = []string{
@@ -22,6 +26,10 @@ var optionStr = []string{
 	"html",
 	"spdx",
 	"template",
+	"sarif",
+	"junit",
+	"cyclonedx-json",
+	"cyclonedx-xml",
 }
 
 var Options = []Option{
@@ -32,6 +40,10 @@ var Options = []Option{
 	HTMLPresenter,
 	SPDXPresenter,
 	TemplatePresenter,
+	SARIFPresenter,
+	JUnitPresenter,
+	CycloneDXJSONPresenter,
+	CycloneDXXMLPresenter,
 }
 
 type Option int
@@ -52,6 +64,14 @@ func ParseOption(userStr string) Option {
 		return SPDXPresenter
 	case "template": // Directly check for "template" string
 		return TemplatePresenter
+	case SARIFPresenter.String():
+		return SARIFPresenter
+	case JUnitPresenter.String():
+		return JUnitPresenter
+	case CycloneDXJSONPresenter.String(), "cyclonedx":
+		return CycloneDXJSONPresenter
+	case CycloneDXXMLPresenter.String():
+		return CycloneDXXMLPresenter
 	default:
 		return UnknownPresenter
 	}
@@ -5,9 +5,12 @@ import (
 
 	"github.com/khulnasoft/go-licenses/golicenses"
 	"github.com/khulnasoft/go-licenses/golicenses/presenter/csv"
+	"github.com/khulnasoft/go-licenses/golicenses/presenter/cyclonedx"
 	"github.com/khulnasoft/go-licenses/golicenses/presenter/html"
 	"github.com/khulnasoft/go-licenses/golicenses/presenter/json"
+	"github.com/khulnasoft/go-licenses/golicenses/presenter/junit"
 	"github.com/khulnasoft/go-licenses/golicenses/presenter/markdown"
+	"github.com/khulnasoft/go-licenses/golicenses/presenter/sarif"
 	"github.com/khulnasoft/go-licenses/golicenses/presenter/spdx" // Placeholder for SPDX presenter
 	templatepresenter "github.com/khulnasoft/go-licenses/golicenses/presenter/template"
 	"github.com/khulnasoft/go-licenses/golicenses/presenter/text"
@@ -31,6 +34,14 @@ func GetPresenter(option Option, results <-chan golicenses.LicenseResult, templa
 		return html.NewPresenter(results)
 	case SPDXPresenter:
 		return spdx.NewPresenter(results)
+	case SARIFPresenter:
+		return sarif.NewPresenter(results)
+	case JUnitPresenter:
+		return junit.NewPresenter(results)
+	case CycloneDXJSONPresenter:
+		return cyclonedx.NewPresenter(results, cyclonedx.JSON)
+	case CycloneDXXMLPresenter:
+		return cyclonedx.NewPresenter(results, cyclonedx.XML)
 	case TemplatePresenter: // TemplatePresenter, since Option is int and not in optionStr, use explicit value
 		if len(templatePath) == 0 {
 			return nil
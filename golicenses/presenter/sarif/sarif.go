@@ -0,0 +1,284 @@
+package sarif
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/khulnasoft/go-licenses/golicenses"
+	"golang.org/x/mod/modfile"
+)
+
+const (
+	sarifSchema  = "https://json.schemastore.org/sarif-2.1.0.json"
+	sarifVersion = "2.1.0"
+)
+
+// Presenter outputs the findings of the results (see golicenses.AnnotateResults)
+// as a SARIF 2.1.0 log, with one result per finding. Each policy rule that
+// matched is a SARIF rule, identified by its name or by its action, kind,
+// license (type) and scope (see golicenses.Rule.ID), e.g.
+// "golicenses/deny/license/GPL-.*". Findings are located at
+// the require line of their module in the go.mod of the main module, which is
+// found in the directory of the results without a module version.
+// https://docs.oasis-open.org/sarif/sarif/v2.1.0/sarif-v2.1.0.html
+type Presenter struct {
+	results <-chan golicenses.LicenseResult
+}
+
+// NewPresenter creates a new SARIF presenter.
+func NewPresenter(results <-chan golicenses.LicenseResult) *Presenter {
+	return &Presenter{results: results}
+}
+
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID                   string             `json:"id"`
+	ShortDescription     sarifMessage       `json:"shortDescription"`
+	Help                 sarifMessage       `json:"help"`
+	DefaultConfiguration sarifConfiguration `json:"defaultConfiguration"`
+}
+
+type sarifConfiguration struct {
+	Level string `json:"level"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifResult struct {
+	RuleID     string            `json:"ruleId"`
+	RuleIndex  int               `json:"ruleIndex"`
+	Level      string            `json:"level"`
+	Message    sarifMessage      `json:"message"`
+	Locations  []sarifLocation   `json:"locations,omitempty"`
+	Properties map[string]string `json:"properties,omitempty"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           *sarifRegion          `json:"region,omitempty"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type sarifRegion struct {
+	StartLine int `json:"startLine"`
+}
+
+// goMod is a go.mod file of a main module.
+type goMod struct {
+	uri string
+	// lines maps the main and required module paths to their line.
+	lines map[string]int
+}
+
+// Present writes the SARIF log to the given writer.
+func (p *Presenter) Present(w io.Writer) error {
+	var results []golicenses.LicenseResult
+	for res := range p.results {
+		results = append(results, res)
+	}
+	goMods := findGoMods(results)
+
+	run := sarifRun{
+		Tool: sarifTool{Driver: sarifDriver{
+			Name:           "golicenses",
+			InformationURI: "https://github.com/khulnasoft/go-licenses",
+			Rules:          make([]sarifRule, 0),
+		}},
+		Results: make([]sarifResult, 0),
+	}
+	ruleIndex := make(map[string]int)
+	for _, res := range results {
+		module := res.Module
+		if module == "" {
+			module = res.Library
+		}
+		label := golicenses.ModuleLabel(res)
+		for _, f := range res.Findings {
+			id := ruleID(f)
+			idx, ok := ruleIndex[id]
+			if !ok {
+				idx = len(run.Tool.Driver.Rules)
+				ruleIndex[id] = idx
+				run.Tool.Driver.Rules = append(run.Tool.Driver.Rules, sarifRule{
+					ID:                   id,
+					ShortDescription:     sarifMessage{Text: ruleDescription(f)},
+					Help:                 sarifMessage{Text: ruleHelp(f.Action)},
+					DefaultConfiguration: sarifConfiguration{Level: actionLevel(f.Action)},
+				})
+			}
+			run.Results = append(run.Results, sarifResult{
+				RuleID:    id,
+				RuleIndex: idx,
+				Level:     level(f.Severity),
+				Message:   sarifMessage{Text: label + ": " + f.Message},
+				Locations: locate(goMods, module),
+				Properties: map[string]string{
+					"library":  res.Library,
+					"module":   module,
+					"version":  res.Version,
+					"license":  res.License,
+					"rule":     f.Rule,
+					"severity": f.Severity.String(),
+				},
+			})
+		}
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	return enc.Encode(sarifLog{Schema: sarifSchema, Version: sarifVersion, Runs: []sarifRun{run}})
+}
+
+// ruleID returns the SARIF rule ID of the policy rule behind a finding.
+func ruleID(f golicenses.Finding) string {
+	if f.RuleID == "" {
+		return "golicenses/" + strings.ToLower(f.Action.String())
+	}
+	return "golicenses/" + f.RuleID
+}
+
+// ruleDescription describes the policy rule behind a finding.
+func ruleDescription(f golicenses.Finding) string {
+	switch f.Action {
+	case golicenses.DenyAction:
+		return "License forbidden by " + f.Rule
+	case golicenses.ReviewAction:
+		return "License needs review by " + f.Rule
+	case golicenses.AllowAction:
+		return "License allowed by " + f.Rule
+	default:
+		return "License finding of " + f.Rule
+	}
+}
+
+// ruleHelp tells how to resolve the findings of rules with an action.
+func ruleHelp(act golicenses.Action) string {
+	switch act {
+	case golicenses.DenyAction:
+		return "Replace or remove the module, assert its license with an override if it was detected wrongly, or accept it with an exception."
+	case golicenses.ReviewAction:
+		return "Review the license of the module, then allow it with a rule or accept it with an exception."
+	default:
+		return "The license is reported for information only."
+	}
+}
+
+// actionLevel is the default SARIF level of rules with an action; findings
+// set their own level from their severity.
+func actionLevel(act golicenses.Action) string {
+	switch act {
+	case golicenses.DenyAction:
+		return "error"
+	case golicenses.ReviewAction:
+		return "warning"
+	default:
+		return "note"
+	}
+}
+
+// level maps a finding severity to a SARIF result level.
+func level(s golicenses.Severity) string {
+	switch s {
+	case golicenses.ErrorSeverity:
+		return "error"
+	case golicenses.WarnSeverity:
+		return "warning"
+	default:
+		return "note"
+	}
+}
+
+// findGoMods reads the go.mod of the main modules, the results without a
+// module version whose directory has a go.mod declaring their module.
+func findGoMods(results []golicenses.LicenseResult) []goMod {
+	seen := make(map[string]bool)
+	var goMods []goMod
+	for _, res := range results {
+		if res.Module == "" || res.Version != "" || res.Dir == "" || seen[res.Dir] {
+			continue
+		}
+		seen[res.Dir] = true
+		path := filepath.Join(res.Dir, "go.mod")
+		content, err := os.ReadFile(path)
+		if err != nil {
+			continue
+		}
+		f, err := modfile.ParseLax(path, content, nil)
+		if err != nil || f.Module == nil || f.Module.Mod.Path != res.Module {
+			continue
+		}
+		lines := map[string]int{res.Module: f.Module.Syntax.Start.Line}
+		for _, r := range f.Require {
+			lines[r.Mod.Path] = r.Syntax.Start.Line
+		}
+		goMods = append(goMods, goMod{uri: artifactURI(path), lines: lines})
+	}
+	return goMods
+}
+
+// artifactURI returns the path relative to the working directory, like the
+// repository root in CI, or a file URI for paths outside of it.
+func artifactURI(path string) string {
+	if wd, err := os.Getwd(); err == nil {
+		if rel, err := filepath.Rel(wd, path); err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			return filepath.ToSlash(rel)
+		}
+	}
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		abs = path
+	}
+	return "file://" + filepath.ToSlash(abs)
+}
+
+// locate returns the line of a module in the first go.mod that mentions it,
+// or the first go.mod if none does.
+func locate(goMods []goMod, module string) []sarifLocation {
+	if len(goMods) == 0 {
+		return nil
+	}
+	for _, m := range goMods {
+		if line, ok := m.lines[module]; ok {
+			return []sarifLocation{{PhysicalLocation: sarifPhysicalLocation{
+				ArtifactLocation: sarifArtifactLocation{URI: m.uri},
+				Region:           &sarifRegion{StartLine: line},
+			}}}
+		}
+	}
+	return []sarifLocation{{PhysicalLocation: sarifPhysicalLocation{
+		ArtifactLocation: sarifArtifactLocation{URI: goMods[0].uri},
+	}}}
+}
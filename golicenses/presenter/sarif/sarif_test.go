@@ -0,0 +1,104 @@
+package sarif
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/khulnasoft/go-licenses/golicenses"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSARIFPresenter_Present(t *testing.T) {
+	dir := t.TempDir()
+	goMod := "module example.com/app\n\ngo 1.21\n\nrequire (\n\tgithub.com/gpl/lib v1.0.0\n\tgithub.com/mit/lib v1.2.0\n)\n"
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "go.mod"), []byte(goMod), 0o644))
+
+	deny := golicenses.Finding{
+		Severity: golicenses.WarnSeverity,
+		Action:   golicenses.DenyAction,
+		Rule:     "license rule 'GPL-.*'",
+		RuleID:   "deny/license/GPL-.*",
+		Message:  "license GPL-3.0 is forbidden by license rule 'GPL-.*'",
+	}
+	review := golicenses.Finding{
+		Severity: golicenses.WarnSeverity,
+		Action:   golicenses.ReviewAction,
+		Rule:     "the catch-all rule",
+		RuleID:   "review/catch-all",
+		Message:  "unknown license needs review by the catch-all rule",
+	}
+	agpl := golicenses.Finding{
+		Severity: golicenses.ErrorSeverity,
+		Action:   golicenses.DenyAction,
+		Rule:     "license rule 'AGPL-.*'",
+		RuleID:   "deny/license/AGPL-.*",
+		Message:  "license AGPL-3.0 is forbidden by license rule 'AGPL-.*'",
+	}
+	results := make(chan golicenses.LicenseResult)
+	go func() {
+		defer close(results)
+		results <- golicenses.LicenseResult{Library: "example.com/app", Module: "example.com/app", Dir: dir, License: "MIT"}
+		results <- golicenses.LicenseResult{Library: "github.com/gpl/lib/pkg", Module: "github.com/gpl/lib", Version: "v1.0.0", License: "GPL-3.0", Findings: []golicenses.Finding{deny}}
+		results <- golicenses.LicenseResult{Library: "github.com/mit/lib", Module: "github.com/mit/lib", Version: "v1.2.0", License: "MIT"}
+		results <- golicenses.LicenseResult{Library: "github.com/indirect/lib", Module: "github.com/indirect/lib", Version: "v0.1.0", Findings: []golicenses.Finding{review}}
+		results <- golicenses.LicenseResult{Library: "github.com/agpl/lib", Module: "github.com/agpl/lib", Version: "v2.0.0", License: "AGPL-3.0", Findings: []golicenses.Finding{agpl}}
+	}()
+
+	var buf bytes.Buffer
+	require.NoError(t, NewPresenter(results).Present(&buf))
+
+	var log sarifLog
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &log))
+	assert.Equal(t, "2.1.0", log.Version)
+	require.Len(t, log.Runs, 1)
+	run := log.Runs[0]
+	assert.Equal(t, "golicenses", run.Tool.Driver.Name)
+	require.Len(t, run.Tool.Driver.Rules, 3)
+	assert.Equal(t, "golicenses/deny/license/GPL-.*", run.Tool.Driver.Rules[0].ID)
+	assert.Equal(t, "License forbidden by license rule 'GPL-.*'", run.Tool.Driver.Rules[0].ShortDescription.Text)
+	assert.NotEmpty(t, run.Tool.Driver.Rules[0].Help.Text)
+	// the default level follows the action, not the severity of the first finding
+	assert.Equal(t, "error", run.Tool.Driver.Rules[0].DefaultConfiguration.Level)
+	assert.Equal(t, "golicenses/review/catch-all", run.Tool.Driver.Rules[1].ID)
+	assert.Equal(t, "warning", run.Tool.Driver.Rules[1].DefaultConfiguration.Level)
+	assert.Equal(t, "golicenses/deny/license/AGPL-.*", run.Tool.Driver.Rules[2].ID)
+
+	require.Len(t, run.Results, 3)
+	gpl := run.Results[0]
+	assert.Equal(t, "golicenses/deny/license/GPL-.*", gpl.RuleID)
+	assert.Equal(t, deny.Rule, gpl.Properties["rule"])
+	assert.Equal(t, 0, gpl.RuleIndex)
+	assert.Equal(t, "warning", gpl.Level)
+	assert.Equal(t, "github.com/gpl/lib@v1.0.0: license GPL-3.0 is forbidden by license rule 'GPL-.*'", gpl.Message.Text)
+	require.Len(t, gpl.Locations, 1)
+	assert.Equal(t, "file://"+filepath.ToSlash(filepath.Join(dir, "go.mod")), gpl.Locations[0].PhysicalLocation.ArtifactLocation.URI)
+	require.NotNil(t, gpl.Locations[0].PhysicalLocation.Region)
+	assert.Equal(t, 6, gpl.Locations[0].PhysicalLocation.Region.StartLine)
+
+	// modules that go.mod does not require are located at the file
+	indirect := run.Results[1]
+	assert.Equal(t, "warning", indirect.Level)
+	assert.Equal(t, 1, indirect.RuleIndex)
+	require.Len(t, indirect.Locations, 1)
+	assert.Nil(t, indirect.Locations[0].PhysicalLocation.Region)
+
+	// findings of other deny rules have their own SARIF rule
+	other := run.Results[2]
+	assert.Equal(t, "golicenses/deny/license/AGPL-.*", other.RuleID)
+	assert.Equal(t, 2, other.RuleIndex)
+	assert.Equal(t, "error", other.Level)
+	assert.Equal(t, agpl.Rule, other.Properties["rule"])
+}
+
+func TestSARIFPresenter_NoFindings(t *testing.T) {
+	results := make(chan golicenses.LicenseResult)
+	close(results)
+	var buf bytes.Buffer
+	require.NoError(t, NewPresenter(results).Present(&buf))
+	assert.Contains(t, buf.String(), `"results": []`)
+	assert.Contains(t, buf.String(), `"rules": []`)
+}
@@ -62,16 +62,37 @@ func (p *Presenter) Present(w io.Writer) error {
 			concludedLicense = "NOASSERTION" // Or attempt to parse/map common names
 		}
 		fmt.Fprintf(w, "LicenseConcluded: %s\n", concludedLicense)
-		// LicenseDeclared: Same as Concluded for now, as we don't have separate declared vs. found info.
-		fmt.Fprintf(w, "LicenseDeclared: %s\n", concludedLicense)
-		fmt.Fprintf(w, "PackageLicenseComments: Source path: %s\n", res.Path)
-		fmt.Fprintf(w, "PackageCopyrightText: NOASSERTION\n") // Copyright info not available in LicenseResult
+		if res.ManuallyAsserted {
+			// The license was concluded by a person, not declared by the package.
+			fmt.Fprintf(w, "LicenseDeclared: NOASSERTION\n")
+			fmt.Fprintf(w, "PackageLicenseComments: <text>Manually asserted: %s</text>\n", res.Justification)
+		} else {
+			// LicenseDeclared: Same as Concluded for now, as we don't have separate declared vs. found info.
+			fmt.Fprintf(w, "LicenseDeclared: %s\n", concludedLicense)
+			fmt.Fprintf(w, "PackageLicenseComments: Source path: %s\n", res.Path)
+		}
+		fmt.Fprintf(w, "PackageCopyrightText: %s\n", copyrightText(res.Copyrights))
+		if len(res.Findings) > 0 {
+			findings := make([]string, len(res.Findings))
+			for i, f := range res.Findings {
+				findings[i] = f.String()
+			}
+			fmt.Fprintf(w, "PackageComment: <text>%s</text>\n", strings.Join(findings, "\n"))
+		}
 		fmt.Fprintf(w, "\n")
 	}
 
 	return nil
 }
 
+// copyrightText formats copyright statements as an SPDX text value.
+func copyrightText(copyrights []string) string {
+	if len(copyrights) == 0 {
+		return "NOASSERTION"
+	}
+	return "<text>" + strings.Join(copyrights, "\n") + "</text>"
+}
+
 // generateUUID generates a new UUID string.
 func generateUUID() string {
 	return uuid.NewString()
@@ -22,6 +22,10 @@ func TestSPDXPresenter_Present(t *testing.T) {
 			URL:     "https://github.com/owner/repo1",
 			License: "MIT",
 			Path:    "/path/to/repo1",
+			Copyrights: []string{
+				"Copyright (c) 2019 Owner",
+				"Copyright 2020 Other",
+			},
 		}
 		results <- golicenses.LicenseResult{
 			Library: "gitlab.com/another/project2",
@@ -35,6 +39,12 @@ func TestSPDXPresenter_Present(t *testing.T) {
 			License: "BSD-3-Clause-Invalid",              // To test NOASSERTION
 			Path:    "/path/to/custom",
 		}
+		results <- golicenses.LicenseResult{
+			Library:          "example.com/asserted",
+			License:          "MIT",
+			ManuallyAsserted: true,
+			Justification:    "Confirmed by upstream in issue 42",
+		}
 	}()
 
 	err := p.Present(&outputBuffer)
@@ -58,6 +68,7 @@ func TestSPDXPresenter_Present(t *testing.T) {
 	assert.Contains(t, output, "LicenseConcluded: MIT")
 	assert.Contains(t, output, "LicenseDeclared: MIT")
 	assert.Contains(t, output, "PackageLicenseComments: Source path: /path/to/repo1")
+	assert.Contains(t, output, "PackageCopyrightText: <text>Copyright (c) 2019 Owner\nCopyright 2020 Other</text>")
 
 	// Package 2: gitlab.com/another/project2
 	assert.Contains(t, output, "PackageName: gitlab.com/another/project2")
@@ -65,6 +76,7 @@ func TestSPDXPresenter_Present(t *testing.T) {
 	assert.Contains(t, output, "PackageDownloadLocation: git+https://gitlab.com/another/project2.git")
 	assert.Contains(t, output, "LicenseConcluded: Apache-2.0")
 	assert.Contains(t, output, "LicenseDeclared: Apache-2.0")
+	assert.Contains(t, output, "PackageCopyrightText: NOASSERTION")
 
 	// Package 3: my-custom-lib@v1.2.3 (testing NOASSERTION for invalid license)
 	assert.Contains(t, output, "PackageName: my-custom-lib@v1.2.3")
@@ -72,6 +84,10 @@ func TestSPDXPresenter_Present(t *testing.T) {
 	assert.Contains(t, output, "PackageDownloadLocation: https://example.com/my-custom-lib") // Non-VCS URL remains as is
 	assert.Contains(t, output, "LicenseConcluded: NOASSERTION")
 	assert.Contains(t, output, "LicenseDeclared: NOASSERTION")
+
+	// Package 4: manually asserted license keeps its justification
+	assert.Contains(t, output, "SPDXID: SPDXRef-Package-example.com-asserted\nPackageDownloadLocation: NOASSERTION\nFilesAnalyzed: false\nLicenseConcluded: MIT\nLicenseDeclared: NOASSERTION\n")
+	assert.Contains(t, output, "PackageLicenseComments: <text>Manually asserted: Confirmed by upstream in issue 42</text>")
 }
 
 func TestSanitizeSPDXID(t *testing.T) {
@@ -7,7 +7,8 @@ import (
 	"github.com/khulnasoft/go-licenses/golicenses"
 )
 
-// LicenseResult fields available in templates: Library, URL, Path, License, Type, Errs
+// LicenseResult fields available in templates: Library, Module, Version, URL, Path, License, Type,
+// ManuallyAsserted, Justification, Findings, Errs
 // Example: {{ .Library }} {{ .License }}
 type Presenter struct {
 	results <-chan golicenses.LicenseResult
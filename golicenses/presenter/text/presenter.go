@@ -24,6 +24,12 @@ func (p Presenter) Present(target io.Writer) error {
 	results := make([]string, 0)
 	for result := range p.resultStream {
 		str := fmt.Sprintf("%-60s %-20s %-s", result.Library, result.License, result.Type)
+		if result.ManuallyAsserted {
+			str += " (manually asserted)"
+		}
+		for _, f := range result.Findings {
+			str += "\n    " + f.String()
+		}
 		results = append(results, str)
 	}
 
@@ -1,10 +1,55 @@
 package golicenses
 
+import "github.com/hashicorp/go-multierror"
+
 type LicenseResult struct {
 	Library string
+	// Module and Version identify the Go module containing the library, if known.
+	Module  string
+	Version string
+	// Dir is the directory holding the library's module files, if known.
+	Dir     string
 	URL     string
 	Path    string
 	License string
 	Type    string
-	Errs    error
+	// Source is the kind of file the license was identified from (see licenses.SourceKind).
+	Source string
+	// Copyrights are the copyright statements found for the library.
+	Copyrights []string
+	// NoticePaths are the NOTICE files found in the library's module.
+	NoticePaths []string
+	// DependsOn are the modules imported by the library, if known.
+	DependsOn []string
+	// ManuallyAsserted is set if License and Type were set by an Override
+	// instead of being detected; Justification is the override's reason.
+	ManuallyAsserted bool
+	Justification    string
+	// Findings are the rule violations of the library, see AnnotateResults.
+	Findings []Finding
+	Errs     error
+}
+
+// Errors returns the errors of the result, with those combined into Errs
+// by multierror listed separately.
+func (r LicenseResult) Errors() []error {
+	return unwrapErrors(r.Errs)
+}
+
+func unwrapErrors(err error) []error {
+	if err == nil {
+		return nil
+	}
+	mErr, ok := err.(*multierror.Error)
+	if !ok {
+		return []error{err}
+	}
+	if mErr == nil {
+		return nil
+	}
+	var errs []error
+	for _, err := range mErr.Errors {
+		errs = append(errs, unwrapErrors(err)...)
+	}
+	return errs
 }
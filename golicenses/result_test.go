@@ -0,0 +1,30 @@
+package golicenses
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/go-test/deep"
+	"github.com/hashicorp/go-multierror"
+)
+
+func TestLicenseResult_Errors(t *testing.T) {
+	first, second, third := errors.New("first"), errors.New("second"), errors.New("third")
+	tests := []struct {
+		name string
+		errs error
+		want []error
+	}{
+		{name: "no errors"},
+		{name: "single error", errs: first, want: []error{first}},
+		{name: "nested multierror", errs: multierror.Append(first, multierror.Append(second, third)), want: []error{first, second, third}},
+		{name: "nil multierror", errs: (*multierror.Error)(nil)},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			for _, d := range deep.Equal(test.want, LicenseResult{Errs: test.errs}.Errors()) {
+				t.Errorf("diff: %+v", d)
+			}
+		})
+	}
+}
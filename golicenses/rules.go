@@ -3,94 +3,651 @@ package golicenses
 import (
 	"fmt"
 	"regexp"
+	"strings"
+	"time"
+
+	"github.com/khulnasoft/go-licenses/golicenses/licenses"
 )
 
 const (
 	UnknownAction Action = iota
 	AllowAction
 	DenyAction
+	// ReviewAction flags a result for review without failing the check.
+	ReviewAction
 )
 
 var actionStr = []string{
 	"UnknownAction",
 	"Allow",
 	"Deny",
+	"Review",
 }
 
 type Action int
 
-type Rules struct {
-	Action     Action
-	Patterns   []*regexp.Regexp
-	IgnorePkgs []*regexp.Regexp
+// ParseAction parses the action of a rule: allow (permit), deny (forbid) or review (warn).
+func ParseAction(s string) (Action, error) {
+	switch strings.ToLower(s) {
+	case "allow", "permit":
+		return AllowAction, nil
+	case "deny", "forbid":
+		return DenyAction, nil
+	case "review", "warn":
+		return ReviewAction, nil
+	default:
+		return UnknownAction, fmt.Errorf("bad rule action %q (options=allow, deny, review)", s)
+	}
 }
 
-func NewRules(act Action, patterns []string, ignore ...string) (Rules, error) {
-	if act == UnknownAction {
-		return Rules{}, fmt.Errorf("bad action given: %+v", act)
+// RuleKind is the kind of rule that caught a violation.
+type RuleKind int
+
+const (
+	// LicenseRule matches license names (permit/forbid).
+	LicenseRule RuleKind = iota
+	// TypeRule matches license types (permit-types/forbid-types).
+	TypeRule
+	// CatchAllRule matches any license.
+	CatchAllRule
+)
+
+func (k RuleKind) String() string {
+	switch k {
+	case TypeRule:
+		return "type"
+	case CatchAllRule:
+		return "catch-all"
+	default:
+		return "license"
+	}
+}
+
+// ruleOrigin is the part of the configuration a rule comes from, which sets
+// its precedence (see Rules.Decide).
+type ruleOrigin int
+
+const (
+	// policyRule is an entry of the rules list (see NewRuleList).
+	policyRule ruleOrigin = iota
+	// forbidListRule comes from a forbid or forbid-types list.
+	forbidListRule
+	// permitListRule comes from a permit or permit-types list.
+	permitListRule
+)
+
+// MatchMode selects the rule that decides a result when several rules match it.
+type MatchMode int
+
+const (
+	// FirstMatch selects the first matching rule.
+	FirstMatch MatchMode = iota
+	// MostSpecificMatch selects the most specific matching rule: rules scoped
+	// to a literal module path over a module pattern, over unscoped rules; then
+	// a literal license name over a license pattern, over a license type, over
+	// a catch-all rule. Ties go to the first rule.
+	MostSpecificMatch
+)
+
+// ParseMatchMode parses a rule match mode: "first" (the default) or "most-specific".
+func ParseMatchMode(s string) (MatchMode, error) {
+	switch strings.ToLower(s) {
+	case "", "first":
+		return FirstMatch, nil
+	case "most-specific":
+		return MostSpecificMatch, nil
+	default:
+		return FirstMatch, fmt.Errorf("bad rule match mode %q (options=first, most-specific)", s)
+	}
+}
+
+// Rule decides the outcome for results whose license matches a license name
+// pattern and/or a license type. A rule without either matches any license.
+// Module and Version scope the rule to some modules.
+type Rule struct {
+	// Module is a regular expression matched against the whole module path.
+	Module string `mapstructure:"module"`
+	// Version is a version constraint, e.g. ">= v1.2, < v2" (see ParseVersionConstraint).
+	Version string `mapstructure:"version"`
+	// License is a regular expression matched against the whole license name.
+	License string `mapstructure:"license"`
+	// Type is a license type (see licenses.ParseType).
+	Type string `mapstructure:"type"`
+	// Action is allow, deny or review.
+	Action string `mapstructure:"action"`
+	// Severity is info, warn or error; it defaults to error for deny rules
+	// and warn for review rules. Allow rules only report with a severity.
+	Severity string `mapstructure:"severity"`
+	// Name identifies the rule in reports instead of the ID derived from it (see ID).
+	Name string `mapstructure:"name"`
+
+	kind     RuleKind
+	action   Action
+	severity Severity
+	patterns []*regexp.Regexp
+	types    []licenses.Type
+	// negate inverts the match, for rules converted from permit lists
+	negate        bool
+	origin        ruleOrigin
+	description   string
+	specificity   int
+	modulePattern *regexp.Regexp
+	versions      VersionConstraint
+}
+
+// NewRuleList validates rules and prepares them for matching.
+func NewRuleList(rules ...Rule) ([]Rule, error) {
+	compiled := make([]Rule, len(rules))
+	for idx, r := range rules {
+		var err error
+		if r.action, err = ParseAction(r.Action); err != nil {
+			return nil, fmt.Errorf("rule %d: %w", idx+1, err)
+		}
+		r.severity = defaultSeverity(r.action)
+		if r.Severity != "" {
+			if r.severity, err = ParseSeverity(r.Severity); err != nil {
+				return nil, fmt.Errorf("rule %d: %w", idx+1, err)
+			}
+		}
+		r.kind = CatchAllRule
+		if r.Type != "" {
+			licenseType, err := licenses.ParseType(r.Type)
+			if err != nil {
+				return nil, fmt.Errorf("rule %d: %w", idx+1, err)
+			}
+			r.kind, r.types, r.specificity = TypeRule, []licenses.Type{licenseType}, 1
+			r.description = licenseType.String()
+		}
+		if r.License != "" {
+			pattern, err := regexp.Compile("^(?:" + r.License + ")$")
+			if err != nil {
+				return nil, fmt.Errorf("bad rule (%s): %w", r.License, err)
+			}
+			if _, complete := pattern.LiteralPrefix(); complete {
+				r.specificity += 4
+			} else {
+				r.specificity += 2
+			}
+			if r.kind == TypeRule {
+				r.description = fmt.Sprintf("%s (type %s)", r.License, r.description)
+			} else {
+				r.description = r.License
+			}
+			r.kind, r.patterns = LicenseRule, []*regexp.Regexp{pattern}
+		}
+		if r.Module != "" {
+			if r.modulePattern, err = regexp.Compile("^(?:" + r.Module + ")$"); err != nil {
+				return nil, fmt.Errorf("bad rule module pattern (%s): %w", r.Module, err)
+			}
+			if _, complete := r.modulePattern.LiteralPrefix(); complete {
+				r.specificity += 32
+			} else {
+				r.specificity += 16
+			}
+		}
+		if r.versions, err = ParseVersionConstraint(r.Version); err != nil {
+			return nil, fmt.Errorf("rule %d: %w", idx+1, err)
+		}
+		if !r.versions.IsEmpty() {
+			r.specificity += 8
+		}
+		compiled[idx] = r
 	}
+	return compiled, nil
+}
 
-	regexPatterns := make([]*regexp.Regexp, len(patterns))
+// LicenseRules converts a permit (AllowAction) or forbid (DenyAction) list of
+// license name patterns to rules. Licenses matching none of the permitted
+// patterns are denied.
+func LicenseRules(act Action, patterns []string) ([]Rule, error) {
+	compiled := make([]*regexp.Regexp, len(patterns))
 	for idx, a := range patterns {
 		pattern, err := regexp.Compile(a)
 		if err != nil {
-			return Rules{}, fmt.Errorf("bad rule (%s): %w", a, err)
+			return nil, fmt.Errorf("bad rule (%s): %w", a, err)
+		}
+		compiled[idx] = pattern
+	}
+	switch act {
+	case AllowAction:
+		return []Rule{{kind: LicenseRule, action: DenyAction, severity: ErrorSeverity, patterns: compiled, negate: true, origin: permitListRule}}, nil
+	case DenyAction:
+		rules := make([]Rule, len(compiled))
+		for idx, p := range compiled {
+			rules[idx] = Rule{kind: LicenseRule, action: DenyAction, severity: ErrorSeverity, patterns: []*regexp.Regexp{p}, description: p.String(), specificity: 2, origin: forbidListRule}
+		}
+		return rules, nil
+	default:
+		return nil, fmt.Errorf("bad action given: %+v", act)
+	}
+}
+
+// TypeRules converts a permit-types (AllowAction) or forbid-types
+// (DenyAction) list to rules. Licenses of none of the permitted types are denied.
+func TypeRules(act Action, types []string) ([]Rule, error) {
+	parsed, err := ParseTypes(types)
+	if err != nil {
+		return nil, err
+	}
+	switch act {
+	case AllowAction:
+		return []Rule{{kind: TypeRule, action: DenyAction, severity: ErrorSeverity, types: parsed, negate: true, origin: permitListRule}}, nil
+	case DenyAction:
+		rules := make([]Rule, len(parsed))
+		for idx, t := range parsed {
+			rules[idx] = Rule{kind: TypeRule, action: DenyAction, severity: ErrorSeverity, types: []licenses.Type{t}, description: t.String(), specificity: 1, origin: forbidListRule}
+		}
+		return rules, nil
+	default:
+		return nil, fmt.Errorf("bad action given: %+v", act)
+	}
+}
+
+// defaultSeverity is the severity of rules that don't set one.
+func defaultSeverity(act Action) Severity {
+	switch act {
+	case DenyAction:
+		return ErrorSeverity
+	case ReviewAction:
+		return WarnSeverity
+	default:
+		return NoSeverity
+	}
+}
+
+// ParseTypes parses the license types of type rules (see licenses.ParseType).
+func ParseTypes(types []string) ([]licenses.Type, error) {
+	parsed := make([]licenses.Type, len(types))
+	for idx, t := range types {
+		licenseType, err := licenses.ParseType(t)
+		if err != nil {
+			return nil, fmt.Errorf("bad type rule: %w", err)
+		}
+		parsed[idx] = licenseType
+	}
+	return parsed, nil
+}
+
+// InScope reports whether a result belongs to the modules the rule is scoped to.
+// Results without module information are matched by library name.
+func (r Rule) InScope(res LicenseResult) bool {
+	module := res.Module
+	if module == "" {
+		module = res.Library
+	}
+	if r.modulePattern != nil && !r.modulePattern.MatchString(module) {
+		return false
+	}
+	return r.versions.Check(res.Version)
+}
+
+// Scope describes the modules the rule is scoped to; it is empty for rules
+// that apply to all modules.
+func (r Rule) Scope() string {
+	if r.modulePattern == nil && r.versions.IsEmpty() {
+		return ""
+	}
+	module := r.Module
+	if module == "" {
+		module = "all modules"
+	}
+	if r.versions.IsEmpty() {
+		return module
+	}
+	return module + " " + r.versions.String()
+}
+
+// ID identifies the rule in reports, e.g. as SARIF rule: its name, or its
+// action, kind, license (type) and scope, e.g. "deny/license/GPL-.*@github.com/foo/.*".
+func (r Rule) ID() string {
+	if r.Name != "" {
+		return r.Name
+	}
+	id := strings.ToLower(r.action.String()) + "/" + r.kind.String()
+	switch {
+	case r.negate:
+		id += "/not-permitted"
+	case r.kind != CatchAllRule:
+		id += "/" + r.description
+	}
+	if scope := r.Scope(); scope != "" {
+		id += "@" + scope
+	}
+	return id
+}
+
+// Matches reports whether the rule applies to a result.
+func (r Rule) Matches(res LicenseResult) bool {
+	if !r.InScope(res) {
+		return false
+	}
+	match := true
+	if r.kind == LicenseRule {
+		match = false
+		for _, p := range r.patterns {
+			if p.MatchString(res.License) {
+				match = true
+				break
+			}
+		}
+	}
+	if match && (r.kind == TypeRule || len(r.types) > 0) {
+		// a type the rules can't know about is treated like an unknown license
+		licenseType, _ := licenses.ParseType(res.Type)
+		match = false
+		for _, t := range r.types {
+			if t == licenseType {
+				match = true
+				break
+			}
 		}
-		regexPatterns[idx] = pattern
 	}
+	return match != r.negate
+}
+
+type Rules struct {
+	// List is evaluated in order against each result; results no rule
+	// matches are allowed.
+	List       []Rule
+	Match      MatchMode
+	IgnorePkgs []IgnorePkg
+	// Exceptions accept matching violations until they expire (see NewExceptions).
+	Exceptions []Exception
+}
+
+// Violation is a result that a rule denied or flagged for review.
+type Violation struct {
+	Result LicenseResult
+	Kind   RuleKind
+	// Action is DenyAction or ReviewAction (AllowAction in Explain).
+	Action Action
+	// Rule describes the rule that matched; it is empty when the license
+	// (type) matched none of the permitted ones.
+	Rule string
+	// Scope is the scope of the rule that matched (see Rule.Scope).
+	Scope string
+	// RuleID identifies the rule that matched (see Rule.ID).
+	RuleID   string
+	Severity Severity
+}
+
+func (v Violation) String() string {
+	return v.Result.Library + ": " + v.Message()
+}
+
+// Message describes the violation without the library name.
+func (v Violation) Message() string {
+	subject := "license " + v.Result.License
+	if v.Result.License == "" {
+		subject = "unknown license"
+	}
+	if v.Kind == TypeRule {
+		subject = "type " + v.Result.Type
+		if v.Result.Type == "" {
+			subject = "type unknown"
+		}
+	}
+
+	if v.Kind != CatchAllRule && v.Rule == "" {
+		return fmt.Sprintf("%s is not permitted by any %s rule", subject, v.Kind)
+	}
+	rule := v.RuleString()
+	switch v.Action {
+	case AllowAction:
+		return fmt.Sprintf("%s is allowed by %s", subject, rule)
+	case ReviewAction:
+		return fmt.Sprintf("%s needs review by %s", subject, rule)
+	default:
+		return fmt.Sprintf("%s is forbidden by %s", subject, rule)
+	}
+}
+
+// RuleString describes the rule that matched, including its kind and scope.
+func (v Violation) RuleString() string {
+	var rule string
+	switch {
+	case v.Kind == CatchAllRule:
+		rule = "the catch-all rule"
+	case v.Rule == "":
+		rule = fmt.Sprintf("the permitted %s rules", v.Kind)
+	default:
+		rule = fmt.Sprintf("%s rule '%s'", v.Kind, v.Rule)
+	}
+	if v.Scope != "" {
+		rule += " for " + v.Scope
+	}
+	return rule
+}
+
+// Finding converts the violation to a finding reported with its result.
+func (v Violation) Finding() Finding {
+	return Finding{Severity: v.Severity, Action: v.Action, Rule: v.RuleString(), RuleID: v.RuleID, Message: v.Message()}
+}
 
-	ignorePatterns := make([]*regexp.Regexp, len(ignore))
+// NewRules creates rules from a permit (AllowAction) or forbid (DenyAction)
+// list of license name patterns (see LicenseRules).
+func NewRules(act Action, patterns []string, ignore ...string) (Rules, error) {
+	list, err := LicenseRules(act, patterns)
+	if err != nil {
+		return Rules{}, err
+	}
+	return NewOrderedRules(FirstMatch, list, ignore...)
+}
+
+// NewOrderedRules creates rules from a rule list (see NewRuleList, LicenseRules and TypeRules).
+func NewOrderedRules(match MatchMode, list []Rule, ignore ...string) (Rules, error) {
+	ignorePatterns := make([]IgnorePkg, len(ignore))
 	for idx, a := range ignore {
-		pattern, err := regexp.Compile(a)
+		pattern, err := ParseIgnorePkg(a)
 		if err != nil {
-			return Rules{}, fmt.Errorf("bad ignore pattern (%s): %w", a, err)
+			return Rules{}, err
 		}
 		ignorePatterns[idx] = pattern
 	}
 
 	return Rules{
-		Action:     act,
-		Patterns:   regexPatterns,
+		List:       list,
+		Match:      match,
 		IgnorePkgs: ignorePatterns,
 	}, nil
 }
 
+// IgnorePkg excludes libraries from the rules, optionally only some versions of them.
+type IgnorePkg struct {
+	raw      string
+	pattern  *regexp.Regexp
+	versions VersionConstraint
+}
+
+// ParseIgnorePkg parses an ignore entry: a regular expression matched against
+// the library name, optionally followed by a version constraint after a space,
+// e.g. "github.com/x/y <= v1.4" (see ParseVersionConstraint).
+func ParseIgnorePkg(s string) (IgnorePkg, error) {
+	entry := strings.TrimSpace(s)
+	library, constraint := entry, ""
+	if idx := strings.IndexAny(entry, " \t"); idx >= 0 {
+		library, constraint = entry[:idx], entry[idx+1:]
+	}
+	pattern, err := regexp.Compile(library)
+	if err != nil {
+		return IgnorePkg{}, fmt.Errorf("bad ignore pattern (%s): %w", library, err)
+	}
+	versions, err := ParseVersionConstraint(constraint)
+	if err != nil {
+		return IgnorePkg{}, fmt.Errorf("bad ignore pattern (%s): %w", s, err)
+	}
+	return IgnorePkg{raw: entry, pattern: pattern, versions: versions}, nil
+}
+
+// Matches reports whether the entry ignores a result.
+func (i IgnorePkg) Matches(res LicenseResult) bool {
+	return i.pattern.MatchString(res.Library) && i.versions.Check(res.Version)
+}
+
+// HasVersions reports whether the entry only ignores some versions.
+func (i IgnorePkg) HasVersions() bool {
+	return !i.versions.IsEmpty()
+}
+
+func (i IgnorePkg) String() string {
+	return i.raw
+}
+
+// Evaluate checks results against the rules at the current time (see EvaluateAt).
 func (r Rules) Evaluate(results ...LicenseResult) (bool, []LicenseResult, error) {
-	mismatched := make([]LicenseResult, 0)
-	matched := make([]LicenseResult, 0)
-resultsLoop:
+	return r.EvaluateAt(time.Now(), results...)
+}
+
+// EvaluateAt checks results against the rules and returns whether none of
+// them had error severity violations, and those results (see ViolationsAt).
+func (r Rules) EvaluateAt(now time.Time, results ...LicenseResult) (bool, []LicenseResult, error) {
+	violations, err := r.ViolationsAt(now, results...)
+	if err != nil {
+		return false, nil, err
+	}
+	denied := make([]LicenseResult, 0, len(violations))
+	for _, v := range violations {
+		if v.Severity >= ErrorSeverity {
+			denied = append(denied, v.Result)
+		}
+	}
+	return len(denied) == 0, denied, nil
+}
+
+// ViolationsAt returns the results denied or flagged for review by the rule
+// that decides them (see MatchMode), and the results allowed by a rule with
+// a severity. Violations covered by an exception that
+// has not expired at the given time are accepted.
+func (r Rules) ViolationsAt(now time.Time, results ...LicenseResult) ([]Violation, error) {
+	violations := make([]Violation, 0)
 	for _, result := range results {
-		licenseName := result.License
-		libName := result.Library
+		if r.Ignored(result) {
+			continue
+		}
+		rule, ok := r.Decide(result)
+		if !ok || rule.severity == NoSeverity {
+			continue
+		}
+		if _, excepted := r.ExceptionFor(result, now); excepted {
+			continue
+		}
+		violations = append(violations, rule.violation(result))
+	}
+	return violations, nil
+}
 
-		for _, i := range r.IgnorePkgs {
-			if i.Match([]byte(libName)) {
-				continue resultsLoop
-			}
+// AnnotateResults returns copies of the results with the findings of their
+// violations (see Rules.ViolationsAt).
+func AnnotateResults(results []LicenseResult, violations []Violation) []LicenseResult {
+	findings := make(map[string][]Finding)
+	for _, v := range violations {
+		findings[v.Result.Library] = append(findings[v.Result.Library], v.Finding())
+	}
+	annotated := make([]LicenseResult, len(results))
+	for idx, res := range results {
+		res.Findings = findings[res.Library]
+		annotated[idx] = res
+	}
+	return annotated
+}
+
+// Ignored reports whether a result is excluded from the rules by IgnorePkgs.
+func (r Rules) Ignored(res LicenseResult) bool {
+	for _, i := range r.IgnorePkgs {
+		if i.Matches(res) {
+			return true
 		}
+	}
+	return false
+}
 
-		match := false
-	patternLoop:
-		for _, p := range r.Patterns {
-			if p.Match([]byte(licenseName)) {
-				match = true
-				break patternLoop
+// UnusedIgnores returns the IgnorePkgs entries that match none of the
+// results, e.g. because the ignored module was upgraded past the reviewed versions.
+func (r Rules) UnusedIgnores(results ...LicenseResult) []IgnorePkg {
+	var unused []IgnorePkg
+	for _, i := range r.IgnorePkgs {
+		used := false
+		for _, res := range results {
+			if i.Matches(res) {
+				used = true
+				break
 			}
 		}
-		if match {
-			matched = append(matched, result)
-		} else {
-			mismatched = append(mismatched, result)
+		if !used {
+			unused = append(unused, i)
+		}
+	}
+	return unused
+}
+
+// Explain describes how the rules decide a result at the given time,
+// including the scope of the deciding rule.
+func (r Rules) Explain(res LicenseResult, now time.Time) string {
+	if r.Ignored(res) {
+		return fmt.Sprintf("%s: ignored", res.Library)
+	}
+	rule, ok := r.Decide(res)
+	if !ok {
+		license := res.License
+		if license == "" {
+			license = "unknown"
 		}
+		return fmt.Sprintf("%s: license %s is allowed, no rule matched", res.Library, license)
+	}
+	explanation := rule.violation(res).String()
+	if e, excepted := r.ExceptionFor(res, now); excepted && rule.action != AllowAction {
+		explanation += fmt.Sprintf(", accepted until %s by exception (%s)", e.Expires, e.Reason)
 	}
+	return explanation
+}
 
-	switch r.Action {
-	case AllowAction:
-		return len(mismatched) == 0, mismatched, nil
-	case DenyAction:
-		return len(matched) == 0, matched, nil
+func (r Rule) violation(res LicenseResult) Violation {
+	return Violation{Result: res, Kind: r.kind, Action: r.action, Rule: r.description, Scope: r.Scope(), RuleID: r.ID(), Severity: r.severity}
+}
+
+// Decide returns the rule that decides a result, if any rule matches it.
+// Wherever they are in the list, rules converted from forbid lists (see
+// LicenseRules and TypeRules) deny the licenses they match unless the
+// deciding entry of the rules list names the license, and rules converted
+// from permit lists only decide results that no other rule matches.
+func (r Rules) Decide(res LicenseResult) (Rule, bool) {
+	rule, ok := r.decide(res, policyRule)
+	if forbid, forbidden := r.decide(res, forbidListRule); forbidden && !(ok && rule.License != "") {
+		return forbid, true
+	}
+	if ok {
+		return rule, true
+	}
+	return r.decide(res, permitListRule)
+}
+
+// decide returns the rule that decides a result among the rules of an
+// origin (see MatchMode).
+func (r Rules) decide(res LicenseResult, origin ruleOrigin) (Rule, bool) {
+	var decision Rule
+	found := false
+	for _, rule := range r.List {
+		if rule.origin != origin || !rule.Matches(res) {
+			continue
+		}
+		if r.Match == FirstMatch {
+			return rule, true
+		}
+		if !found || rule.specificity > decision.specificity {
+			decision, found = rule, true
+		}
+	}
+	return decision, found
+}
+
+// ExceptionFor returns the first exception that accepts a result at the given time.
+func (r Rules) ExceptionFor(res LicenseResult, now time.Time) (Exception, bool) {
+	for _, e := range r.Exceptions {
+		if e.Matches(res) && !e.Expired(now) {
+			return e, true
+		}
 	}
-	return false, nil, fmt.Errorf("could not evaluate action: %s", r.Action)
+	return Exception{}, false
 }
 
 func (o Action) String() string {
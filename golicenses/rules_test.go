@@ -1,8 +1,10 @@
 package golicenses
 
 import (
-	"github.com/go-test/deep"
 	"testing"
+	"time"
+
+	"github.com/go-test/deep"
 )
 
 // TestRules_Evaluate tests the Rules evaluation logic with various allow/deny patterns and edge cases.
@@ -206,3 +208,405 @@ func TestRules_Evaluate(t *testing.T) {
 		}
 	}
 }
+
+func TestRules_ViolationsTypes(t *testing.T) {
+	results := []LicenseResult{
+		{Library: "lib1", License: "MIT", Type: "notice"},
+		{Library: "lib2", License: "GPL-3.0", Type: "restricted"},
+		{Library: "lib3", License: "MPL-2.0", Type: "reciprocal"},
+		{Library: "lib4", License: "", Type: "unknown"},
+	}
+	tests := []struct {
+		name       string
+		act        Action
+		patterns   []string
+		typeAct    Action
+		types      []string
+		violations []Violation
+	}{
+		{
+			name:    "forbid types",
+			typeAct: DenyAction,
+			types:   []string{"restricted", "unknown"},
+			violations: []Violation{
+				{Result: results[1], Kind: TypeRule, Action: DenyAction, Rule: "restricted", RuleID: "deny/type/restricted", Severity: ErrorSeverity},
+				{Result: results[3], Kind: TypeRule, Action: DenyAction, Rule: "unknown", RuleID: "deny/type/unknown", Severity: ErrorSeverity},
+			},
+		},
+		{
+			name:    "permit types",
+			typeAct: AllowAction,
+			types:   []string{"Notice", "reciprocal"},
+			violations: []Violation{
+				{Result: results[1], Kind: TypeRule, Action: DenyAction, RuleID: "deny/type/not-permitted", Severity: ErrorSeverity},
+				{Result: results[3], Kind: TypeRule, Action: DenyAction, RuleID: "deny/type/not-permitted", Severity: ErrorSeverity},
+			},
+		},
+		{
+			name:     "license rules are reported first",
+			act:      DenyAction,
+			patterns: []string{"GPL.*", "MPL.*"},
+			typeAct:  DenyAction,
+			types:    []string{"restricted", "unknown"},
+			violations: []Violation{
+				{Result: results[1], Kind: LicenseRule, Action: DenyAction, Rule: "GPL.*", RuleID: "deny/license/GPL.*", Severity: ErrorSeverity},
+				{Result: results[2], Kind: LicenseRule, Action: DenyAction, Rule: "MPL.*", RuleID: "deny/license/MPL.*", Severity: ErrorSeverity},
+				{Result: results[3], Kind: TypeRule, Action: DenyAction, Rule: "unknown", RuleID: "deny/type/unknown", Severity: ErrorSeverity},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var list []Rule
+			if test.act != UnknownAction {
+				licenseRules, err := LicenseRules(test.act, test.patterns)
+				if err != nil {
+					t.Fatalf("failed to make license rules: %+v", err)
+				}
+				list = append(list, licenseRules...)
+			}
+			typeRules, err := TypeRules(test.typeAct, test.types)
+			if err != nil {
+				t.Fatalf("failed to make type rules: %+v", err)
+			}
+			r, err := NewOrderedRules(FirstMatch, append(list, typeRules...), "lib5")
+			if err != nil {
+				t.Fatalf("failed to make rules: %+v", err)
+			}
+			violations, err := r.ViolationsAt(time.Now(), results...)
+			if err != nil {
+				t.Fatalf("ViolationsAt() error: %+v", err)
+			}
+			for _, d := range deep.Equal(test.violations, violations) {
+				t.Errorf("diff: %+v", d)
+			}
+		})
+	}
+}
+
+func TestRules_OrderedRules(t *testing.T) {
+	results := []LicenseResult{
+		{Library: "lib1", License: "MIT", Type: "notice"},
+		{Library: "lib2", License: "AGPL-3.0", Type: "restricted"},
+		{Library: "lib3", License: "GPL-2.0", Type: "restricted"},
+		{Library: "lib4", License: "MPL-2.0", Type: "reciprocal"},
+	}
+	policy := []Rule{
+		{Type: "notice", Action: "allow"},
+		{Type: "permissive", Action: "allow"},
+		{License: "AGPL-.*", Action: "deny"},
+		{Type: "restricted", Action: "review"},
+		{License: "GPL-2.0", Action: "allow"},
+		{Action: "review"},
+	}
+	tests := []struct {
+		name       string
+		match      MatchMode
+		violations []Violation
+	}{
+		{
+			name:  "first match",
+			match: FirstMatch,
+			violations: []Violation{
+				{Result: results[1], Kind: LicenseRule, Action: DenyAction, Rule: "AGPL-.*", RuleID: "deny/license/AGPL-.*", Severity: ErrorSeverity},
+				{Result: results[2], Kind: TypeRule, Action: ReviewAction, Rule: "restricted", RuleID: "review/type/restricted", Severity: WarnSeverity},
+				{Result: results[3], Kind: CatchAllRule, Action: ReviewAction, RuleID: "review/catch-all", Severity: WarnSeverity},
+			},
+		},
+		{
+			name:  "most specific match",
+			match: MostSpecificMatch,
+			violations: []Violation{
+				{Result: results[1], Kind: LicenseRule, Action: DenyAction, Rule: "AGPL-.*", RuleID: "deny/license/AGPL-.*", Severity: ErrorSeverity},
+				{Result: results[3], Kind: CatchAllRule, Action: ReviewAction, RuleID: "review/catch-all", Severity: WarnSeverity},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			list, err := NewRuleList(policy...)
+			if err != nil {
+				t.Fatalf("NewRuleList() error: %+v", err)
+			}
+			r, err := NewOrderedRules(test.match, list)
+			if err != nil {
+				t.Fatalf("failed to make rules: %+v", err)
+			}
+			violations, err := r.ViolationsAt(time.Now(), results...)
+			if err != nil {
+				t.Fatalf("ViolationsAt() error: %+v", err)
+			}
+			for _, d := range deep.Equal(test.violations, violations) {
+				t.Errorf("diff: %+v", d)
+			}
+
+			allowed, denied, err := r.EvaluateAt(time.Now(), results...)
+			if err != nil || allowed || len(denied) != 1 || denied[0].Library != "lib2" {
+				t.Errorf("EvaluateAt() = (%v, %v, %v), want only lib2 denied", allowed, denied, err)
+			}
+		})
+	}
+}
+
+func TestNewRuleList_Errors(t *testing.T) {
+	for _, rule := range []Rule{
+		{License: "MIT"},
+		{License: "MIT", Action: "maybe"},
+		{License: "MIT(", Action: "deny"},
+		{Type: "copyleft", Action: "deny"},
+	} {
+		if _, err := NewRuleList(rule); err == nil {
+			t.Errorf("NewRuleList(%+v): expected an error", rule)
+		}
+	}
+	if _, err := ParseMatchMode("last"); err == nil {
+		t.Error("ParseMatchMode(): expected an error")
+	}
+}
+
+func TestViolation_String(t *testing.T) {
+	res := LicenseResult{Library: "lib2", License: "GPL-3.0", Type: "restricted"}
+	tests := []struct {
+		violation Violation
+		expected  string
+	}{
+		{Violation{Result: res, Kind: TypeRule, Action: DenyAction, Rule: "restricted"}, "lib2: type restricted is forbidden by type rule 'restricted'"},
+		{Violation{Result: res, Kind: LicenseRule, Action: DenyAction}, "lib2: license GPL-3.0 is not permitted by any license rule"},
+		{Violation{Result: res, Kind: LicenseRule, Action: ReviewAction, Rule: "GPL-.*"}, "lib2: license GPL-3.0 needs review by license rule 'GPL-.*'"},
+		{Violation{Result: res, Kind: CatchAllRule, Action: ReviewAction}, "lib2: license GPL-3.0 needs review by the catch-all rule"},
+	}
+	for _, test := range tests {
+		if actual := test.violation.String(); actual != test.expected {
+			t.Errorf("String() = %q, want %q", actual, test.expected)
+		}
+	}
+	if _, err := ParseTypes([]string{"copyleft"}); err == nil {
+		t.Error("expected an error for an unknown type")
+	}
+}
+
+func TestRule_ID(t *testing.T) {
+	list, err := NewRuleList(
+		Rule{License: "GPL-.*", Action: "deny"},
+		Rule{Module: "github.com/foo/bar", Version: ">= v1.2", License: "LGPL-.*", Action: "allow"},
+		Rule{Type: "reciprocal", Action: "review"},
+		Rule{Action: "review"},
+		Rule{License: "MIT", Action: "allow", Name: "mit"},
+	)
+	if err != nil {
+		t.Fatalf("NewRuleList() error: %+v", err)
+	}
+	permit, err := TypeRules(AllowAction, []string{"notice"})
+	if err != nil {
+		t.Fatalf("TypeRules() error: %+v", err)
+	}
+	var ids []string
+	for _, r := range append(list, permit...) {
+		ids = append(ids, r.ID())
+	}
+	expected := []string{
+		"deny/license/GPL-.*",
+		"allow/license/LGPL-.*@github.com/foo/bar >= v1.2",
+		"review/type/reciprocal",
+		"review/catch-all",
+		"mit",
+		"deny/type/not-permitted",
+	}
+	for _, d := range deep.Equal(expected, ids) {
+		t.Errorf("diff: %+v", d)
+	}
+}
+
+func TestRules_ScopedRules(t *testing.T) {
+	results := []LicenseResult{
+		{Library: "github.com/foo/bar/pkg", Module: "github.com/foo/bar", Version: "v1.3.0", License: "LGPL-2.1", Type: "restricted"},
+		{Library: "github.com/foo/bar/pkg", Module: "github.com/foo/bar", Version: "v1.1.0", License: "LGPL-2.1", Type: "restricted"},
+		{Library: "github.com/other/lib", Module: "github.com/other/lib", Version: "v1.3.0", License: "LGPL-3.0", Type: "restricted"},
+	}
+	policy := []Rule{
+		{License: "LGPL-.*", Action: "deny"},
+		{Module: "github.com/foo/bar", Version: ">= v1.2", License: "LGPL-.*", Action: "allow"},
+	}
+	list, err := NewRuleList(policy...)
+	if err != nil {
+		t.Fatalf("NewRuleList() error: %+v", err)
+	}
+
+	for _, test := range []struct {
+		name  string
+		match MatchMode
+		list  []Rule
+	}{
+		{name: "most specific match", match: MostSpecificMatch, list: list},
+		{name: "first match", match: FirstMatch, list: []Rule{list[1], list[0]}},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			r, err := NewOrderedRules(test.match, test.list)
+			if err != nil {
+				t.Fatalf("failed to make rules: %+v", err)
+			}
+			violations, err := r.ViolationsAt(time.Now(), results...)
+			if err != nil {
+				t.Fatalf("ViolationsAt() error: %+v", err)
+			}
+			expected := []Violation{
+				{Result: results[1], Kind: LicenseRule, Action: DenyAction, Rule: "LGPL-.*", RuleID: "deny/license/LGPL-.*", Severity: ErrorSeverity},
+				{Result: results[2], Kind: LicenseRule, Action: DenyAction, Rule: "LGPL-.*", RuleID: "deny/license/LGPL-.*", Severity: ErrorSeverity},
+			}
+			for _, d := range deep.Equal(expected, violations) {
+				t.Errorf("diff: %+v", d)
+			}
+
+			explanation := r.Explain(results[0], time.Now())
+			if want := "github.com/foo/bar/pkg: license LGPL-2.1 is allowed by license rule 'LGPL-.*' for github.com/foo/bar >= v1.2"; explanation != want {
+				t.Errorf("Explain() = %q, want %q", explanation, want)
+			}
+		})
+	}
+
+	if _, err := NewRuleList(Rule{Module: "github.com/foo/bar", Version: ">= latest", Action: "allow"}); err == nil {
+		t.Error("expected an error for a bad version constraint")
+	}
+}
+
+func TestRules_LegacyRulePrecedence(t *testing.T) {
+	results := []LicenseResult{
+		{Library: "github.com/foo/bar", Module: "github.com/foo/bar", Version: "v1.3.0", License: "LGPL-2.1"},
+		{Library: "github.com/foo/bar/old", Module: "github.com/foo/bar", Version: "v1.1.0", License: "LGPL-2.1"},
+		{Library: "github.com/foo/mit", Module: "github.com/foo/mit", Version: "v1.0.0", License: "MIT"},
+		{Library: "github.com/foo/gpl", Module: "github.com/foo/gpl", Version: "v1.0.0", License: "GPL-2.0"},
+		{Library: "github.com/foo/agpl", Module: "github.com/foo/agpl", Version: "v1.0.0", License: "AGPL-3.0"},
+	}
+	forbid, err := LicenseRules(DenyAction, []string{"LGPL-2.1", "AGPL-3.0"})
+	if err != nil {
+		t.Fatalf("LicenseRules() error: %+v", err)
+	}
+	permit, err := LicenseRules(AllowAction, []string{"MIT"})
+	if err != nil {
+		t.Fatalf("LicenseRules() error: %+v", err)
+	}
+	// a scoped rule overrides forbid lists only if it names the license
+	scoped, err := NewRuleList(
+		Rule{Module: "github.com/foo/bar", Version: ">= v1.2", License: "LGPL-.*", Action: "allow"},
+		Rule{Module: "github.com/foo/agpl", Action: "allow"},
+	)
+	if err != nil {
+		t.Fatalf("NewRuleList() error: %+v", err)
+	}
+	legacy := append(forbid, permit...)
+	expected := []Violation{
+		{Result: results[1], Kind: LicenseRule, Action: DenyAction, Rule: "LGPL-2.1", RuleID: "deny/license/LGPL-2.1", Severity: ErrorSeverity},
+		{Result: results[3], Kind: LicenseRule, Action: DenyAction, RuleID: "deny/license/not-permitted", Severity: ErrorSeverity},
+		{Result: results[4], Kind: LicenseRule, Action: DenyAction, Rule: "AGPL-3.0", RuleID: "deny/license/AGPL-3.0", Severity: ErrorSeverity},
+	}
+
+	for mode, match := range map[string]MatchMode{"first match": FirstMatch, "most specific match": MostSpecificMatch} {
+		for _, test := range []struct {
+			name string
+			list []Rule
+		}{
+			{name: "legacy lists first", list: append(append([]Rule{}, legacy...), scoped...)},
+			{name: "legacy lists last", list: append(append([]Rule{}, scoped...), legacy...)},
+		} {
+			t.Run(mode+"/"+test.name, func(t *testing.T) {
+				r, err := NewOrderedRules(match, test.list)
+				if err != nil {
+					t.Fatalf("failed to make rules: %+v", err)
+				}
+				violations, err := r.ViolationsAt(time.Now(), results...)
+				if err != nil {
+					t.Fatalf("ViolationsAt() error: %+v", err)
+				}
+				for _, d := range deep.Equal(expected, violations) {
+					t.Errorf("diff: %+v", d)
+				}
+				explanation := r.Explain(results[4], time.Now())
+				if want := "github.com/foo/agpl: license AGPL-3.0 is forbidden by license rule 'AGPL-3.0'"; explanation != want {
+					t.Errorf("Explain() = %q, want %q", explanation, want)
+				}
+			})
+		}
+	}
+}
+
+func TestRules_VersionedIgnore(t *testing.T) {
+	results := []LicenseResult{
+		{Library: "github.com/x/y", Version: "v1.4.3", License: "GPL-3.0"},
+		{Library: "github.com/x/z", Version: "v1.5.0", License: "GPL-3.0"},
+	}
+	r, err := NewRules(DenyAction, []string{"GPL.*"}, "github.com/x/y <= v1.4", "github.com/x/z >= v1.0, < v1.5", "github.com/gone")
+	if err != nil {
+		t.Fatalf("failed to make rules: %+v", err)
+	}
+
+	allowed, violations, err := r.Evaluate(results...)
+	if err != nil {
+		t.Fatalf("Evaluate() error: %+v", err)
+	}
+	if allowed || len(violations) != 1 || violations[0].Library != "github.com/x/z" {
+		t.Errorf("Evaluate() = (%v, %+v), want only github.com/x/z to violate", allowed, violations)
+	}
+
+	var unused []string
+	for _, i := range r.UnusedIgnores(results...) {
+		unused = append(unused, i.String())
+	}
+	for _, d := range deep.Equal([]string{"github.com/x/z >= v1.0, < v1.5", "github.com/gone"}, unused) {
+		t.Errorf("diff: %+v", d)
+	}
+
+	if _, err := ParseIgnorePkg("github.com/x/y <= latest"); err == nil {
+		t.Error("expected an error for a bad version constraint")
+	}
+}
+
+func TestRules_Severities(t *testing.T) {
+	results := []LicenseResult{
+		{Library: "lib1", License: "MIT", Type: "notice"},
+		{Library: "lib2", License: "MPL-2.0", Type: "reciprocal"},
+		{Library: "lib3", License: "AGPL-3.0", Type: "restricted"},
+		{Library: "lib4", License: "WTFPL", Type: "unknown"},
+	}
+	list, err := NewRuleList(
+		Rule{License: "MIT", Action: "allow", Severity: "info"},
+		Rule{Type: "reciprocal", Action: "review"},
+		Rule{License: "AGPL-.*", Action: "deny"},
+		Rule{Action: "deny", Severity: "warn"},
+	)
+	if err != nil {
+		t.Fatalf("NewRuleList() error: %+v", err)
+	}
+	r, err := NewOrderedRules(FirstMatch, list)
+	if err != nil {
+		t.Fatalf("failed to make rules: %+v", err)
+	}
+	violations, err := r.ViolationsAt(time.Now(), results...)
+	if err != nil {
+		t.Fatalf("ViolationsAt() error: %+v", err)
+	}
+	var severities []Severity
+	for _, v := range violations {
+		severities = append(severities, v.Severity)
+	}
+	for _, d := range deep.Equal([]Severity{InfoSeverity, WarnSeverity, ErrorSeverity, WarnSeverity}, severities) {
+		t.Errorf("diff: %+v", d)
+	}
+
+	allowed, failed, err := r.Evaluate(results...)
+	if err != nil || allowed || len(failed) != 1 || failed[0].Library != "lib3" {
+		t.Errorf("Evaluate() = (%v, %+v, %v), want only lib3 to fail", allowed, failed, err)
+	}
+
+	annotated := AnnotateResults(results, violations)
+	expected := Finding{Severity: WarnSeverity, Action: DenyAction, Rule: "the catch-all rule", RuleID: "deny/catch-all", Message: "license WTFPL is forbidden by the catch-all rule"}
+	for _, d := range deep.Equal([]Finding{expected}, annotated[3].Findings) {
+		t.Errorf("diff: %+v", d)
+	}
+	if results[3].Findings != nil {
+		t.Error("AnnotateResults() must not modify the given results")
+	}
+
+	if _, err := NewRuleList(Rule{Action: "deny", Severity: "fatal"}); err == nil {
+		t.Error("expected an error for a bad severity")
+	}
+}
@@ -0,0 +1,172 @@
+package golicenses
+
+import (
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/khulnasoft/go-licenses/golicenses/licenses"
+)
+
+var copyrightFileRegexp = regexp.MustCompile(`^(?i)(COPYRIGHT|AUTHORS|CONTRIBUTORS)(\..*)?$`)
+
+// Save copies the license, NOTICE and copyright files of each result into a
+// per-module directory under savePath. The full module source is copied for
+// libraries under restricted or reciprocal licenses, since those licenses
+// require the source to be made available, except for the main module and
+// any module containing savePath.
+// An existing savePath is only replaced when force is set.
+func Save(savePath string, force bool, results ...LicenseResult) error {
+	if _, err := os.Stat(savePath); err == nil {
+		if !force {
+			return fmt.Errorf("%s already exists (use --force to overwrite)", savePath)
+		}
+		if err := os.RemoveAll(savePath); err != nil {
+			return fmt.Errorf("unable to remove %s: %w", savePath, err)
+		}
+	} else if !os.IsNotExist(err) {
+		return err
+	}
+
+	for _, res := range results {
+		if err := saveResult(savePath, res); err != nil {
+			return fmt.Errorf("unable to save %s: %w", res.Library, err)
+		}
+	}
+	return nil
+}
+
+// saveResult copies the files of a single result into its module directory.
+func saveResult(savePath string, res LicenseResult) error {
+	name := res.Module
+	if name == "" {
+		name = res.Library
+	}
+	target := filepath.Join(savePath, filepath.FromSlash(name))
+
+	root := res.Dir
+	if root == "" && res.Path != "" {
+		root = filepath.Dir(res.Path)
+	}
+
+	if requiresSource(res.Type) && root != "" && !isMainModule(res) && !contains(root, savePath) {
+		return copyTree(root, target)
+	}
+
+	files := make([]string, 0, len(res.NoticePaths)+1)
+	if res.Path != "" {
+		files = append(files, res.Path)
+	}
+	files = append(files, res.NoticePaths...)
+	for _, dir := range uniqueDirs(root, res.Path) {
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			return err
+		}
+		for _, e := range entries {
+			if e.Type().IsRegular() && copyrightFileRegexp.MatchString(e.Name()) {
+				files = append(files, filepath.Join(dir, e.Name()))
+			}
+		}
+	}
+
+	for _, f := range files {
+		rel := filepath.Base(f)
+		if root != "" {
+			if r, err := filepath.Rel(root, f); err == nil && !strings.HasPrefix(r, "..") {
+				rel = r
+			}
+		}
+		if err := copyFile(f, filepath.Join(target, rel)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// isMainModule reports whether a result belongs to the main module, the only
+// module without a version.
+func isMainModule(res LicenseResult) bool {
+	return res.Module != "" && res.Version == ""
+}
+
+// contains reports whether path is dir or inside of it.
+func contains(dir, path string) bool {
+	absDir, err := filepath.Abs(dir)
+	if err != nil {
+		return false
+	}
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return false
+	}
+	rel, err := filepath.Rel(absDir, absPath)
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
+
+// requiresSource reports whether the license type obliges redistributing the source.
+func requiresSource(licenseType string) bool {
+	return licenseType == licenses.Restricted.String() || licenseType == licenses.Reciprocal.String()
+}
+
+// uniqueDirs returns the module root and the directory of the license file,
+// skipping empty and repeated entries.
+func uniqueDirs(root, licensePath string) []string {
+	var dirs []string
+	if root != "" {
+		dirs = append(dirs, root)
+	}
+	if licensePath != "" {
+		if dir := filepath.Dir(licensePath); dir != root {
+			dirs = append(dirs, dir)
+		}
+	}
+	return dirs
+}
+
+// copyTree copies all regular files under src to dst, skipping VCS metadata.
+func copyTree(src, dst string) error {
+	return filepath.WalkDir(src, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() && path != src && (d.Name() == ".git" || d.Name() == ".hg" || d.Name() == ".svn") {
+			return filepath.SkipDir
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		return copyFile(path, filepath.Join(dst, rel))
+	})
+}
+
+// copyFile copies src to dst, creating parent directories as needed.
+// The copy is always writable, even if src (e.g. in the module cache) is not.
+func copyFile(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	if err := os.MkdirAll(filepath.Dir(dst), 0o755); err != nil {
+		return err
+	}
+	out, err := os.OpenFile(dst, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o644)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
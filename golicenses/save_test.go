@@ -0,0 +1,120 @@
+package golicenses
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/go-test/deep"
+)
+
+func TestSave(t *testing.T) {
+	src := t.TempDir()
+	write := func(name, content string) string {
+		path := filepath.Join(src, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o444); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+	mitDir := filepath.Join(src, "mit")
+	mitLicense := write("mit/LICENSE", "MIT")
+	mitNotice := write("mit/sub/NOTICE", "notice")
+	write("mit/AUTHORS", "authors")
+	write("mit/code.go", "package mit")
+
+	gplDir := filepath.Join(src, "gpl")
+	gplLicense := write("gpl/COPYING", "GPL")
+	write("gpl/code.go", "package gpl")
+	write("gpl/.git/config", "")
+
+	results := []LicenseResult{
+		{Library: "example.com/mit/pkg", Module: "example.com/mit", Version: "v1.0.0", Dir: mitDir, Path: mitLicense, Type: "notice", NoticePaths: []string{mitNotice}},
+		{Library: "example.com/gpl", Module: "example.com/gpl", Version: "v1.0.0", Dir: gplDir, Path: gplLicense, Type: "restricted"},
+		{Library: "example.com/unlicensed"},
+	}
+
+	savePath := filepath.Join(t.TempDir(), "third_party")
+	if err := Save(savePath, false, results...); err != nil {
+		t.Fatalf("Save() error: %v", err)
+	}
+
+	expected := []string{
+		"example.com/gpl/COPYING",
+		"example.com/gpl/code.go",
+		"example.com/mit/AUTHORS",
+		"example.com/mit/LICENSE",
+		"example.com/mit/sub/NOTICE",
+	}
+	for _, d := range deep.Equal(expected, listFiles(t, savePath)) {
+		t.Errorf("diff: %+v", d)
+	}
+
+	err := Save(savePath, false, results...)
+	if err == nil || !strings.Contains(err.Error(), "--force") {
+		t.Errorf("expected error about existing save path, got %v", err)
+	}
+	if err := Save(savePath, true, results[0]); err != nil {
+		t.Fatalf("Save() with force error: %v", err)
+	}
+	if got := len(listFiles(t, savePath)); got != 3 {
+		t.Errorf("expected save path to be replaced with 3 files, got %d", got)
+	}
+}
+
+func TestSave_MainModuleSource(t *testing.T) {
+	project := t.TempDir()
+	for name, content := range map[string]string{"COPYING": "GPL", "main.go": "package main", "internal/x.go": "package internal"} {
+		path := filepath.Join(project, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	main := LicenseResult{Library: "example.com/app", Module: "example.com/app", Dir: project, Path: filepath.Join(project, "COPYING"), Type: "restricted"}
+	replaced := LicenseResult{Library: "example.com/dep", Module: "example.com/dep", Version: "v1.0.0", Dir: project, Path: filepath.Join(project, "COPYING"), Type: "restricted"}
+
+	// the output directory is inside the project, whose source is not copied
+	savePath := filepath.Join(project, "third_party")
+	if err := Save(savePath, false, main, replaced); err != nil {
+		t.Fatalf("Save() error: %v", err)
+	}
+	expected := []string{"example.com/app/COPYING", "example.com/dep/COPYING"}
+	for _, d := range deep.Equal(expected, listFiles(t, savePath)) {
+		t.Errorf("diff: %+v", d)
+	}
+
+	// the main module's source is not copied into other output directories either
+	savePath = filepath.Join(t.TempDir(), "third_party")
+	if err := Save(savePath, false, main); err != nil {
+		t.Fatalf("Save() error: %v", err)
+	}
+	for _, d := range deep.Equal([]string{"example.com/app/COPYING"}, listFiles(t, savePath)) {
+		t.Errorf("diff: %+v", d)
+	}
+}
+
+func listFiles(t *testing.T, root string) []string {
+	t.Helper()
+	var files []string
+	err := filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() {
+			return err
+		}
+		rel, err := filepath.Rel(root, path)
+		files = append(files, filepath.ToSlash(rel))
+		return err
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sort.Strings(files)
+	return files
+}
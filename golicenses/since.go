@@ -0,0 +1,306 @@
+package golicenses
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"golang.org/x/mod/modfile"
+	"golang.org/x/mod/semver"
+	git "gopkg.in/src-d/go-git.v4"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+)
+
+// ChangeKind is how a library's module changed since a base git ref.
+type ChangeKind int
+
+const (
+	Unchanged ChangeKind = iota
+	// ModuleAdded means the module was not required at the base ref.
+	ModuleAdded
+	// ModuleUpgraded means the module version changed, usually an upgrade.
+	ModuleUpgraded
+	// LicenseChanged means the license file, tracked in the repository, changed.
+	LicenseChanged
+)
+
+var changeKindStr = []string{
+	"unchanged",
+	"added",
+	"upgraded",
+	"license changed",
+}
+
+func (k ChangeKind) String() string {
+	if int(k) >= len(changeKindStr) || k < 0 {
+		return changeKindStr[0]
+	}
+	return changeKindStr[k]
+}
+
+// GitBase is the module set of a Go module at a base git ref.
+type GitBase struct {
+	Ref string
+	// MainModule is the module path declared by go.mod at the ref.
+	MainModule string
+	// Modules maps the module paths required at the ref to their versions.
+	Modules map[string]string
+
+	commit *object.Commit
+	root   string
+	// replaced and currentReplaced map the paths of replaced modules to their
+	// replacement (see readReplacements) at the ref and in the working tree.
+	replaced        map[string]string
+	currentReplaced map[string]string
+}
+
+// ReadGitBase reads go.mod and go.sum of the Go module containing dir at a
+// ref of the Git repository containing dir. Modules missing from go.mod (as
+// with go versions before 1.17) are taken from go.sum, at their highest version.
+func ReadGitBase(dir, ref string) (*GitBase, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, err
+	}
+	repo, err := git.PlainOpenWithOptions(dir, &git.PlainOpenOptions{DetectDotGit: true})
+	if err != nil {
+		return nil, fmt.Errorf("unable to open the Git repository of %s: %w", dir, err)
+	}
+	wt, err := repo.Worktree()
+	if err != nil {
+		return nil, err
+	}
+	commit, err := resolveCommit(repo, ref)
+	if err != nil {
+		return nil, fmt.Errorf("unable to resolve %q: %w", ref, err)
+	}
+
+	base := &GitBase{Ref: ref, commit: commit, root: wt.Filesystem.Root()}
+	modDir, err := findGoModDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	rel, err := filepath.Rel(base.root, modDir)
+	if err != nil {
+		return nil, err
+	}
+
+	base.MainModule, base.Modules, err = readModules(commit, rel)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read the modules at %s: %w", ref, err)
+	}
+	goMod, _, err := readCommitFile(commit, filepath.Join(rel, "go.mod"))
+	if err != nil {
+		return nil, err
+	}
+	if base.replaced, err = readReplacements(goMod); err != nil {
+		return nil, fmt.Errorf("unable to read the replace directives at %s: %w", ref, err)
+	}
+	current, err := os.ReadFile(filepath.Join(modDir, "go.mod"))
+	if err != nil {
+		return nil, err
+	}
+	if base.currentReplaced, err = readReplacements(string(current)); err != nil {
+		return nil, fmt.Errorf("unable to read the replace directives: %w", err)
+	}
+	return base, nil
+}
+
+func replacementLabel(replacement string) string {
+	if replacement == "" {
+		return "none"
+	}
+	return replacement
+}
+
+// readReplacements maps the paths of the modules that the replace directives
+// of go.mod content apply to, to their replacement: "path@version" for
+// modules, or the path of local directories.
+func readReplacements(goMod string) (map[string]string, error) {
+	replaced := make(map[string]string)
+	if goMod == "" {
+		return replaced, nil
+	}
+	// ParseLax would skip the replace directives
+	f, err := modfile.Parse("go.mod", []byte(goMod), nil)
+	if err != nil {
+		return nil, err
+	}
+	required := make(map[string]string)
+	for _, r := range f.Require {
+		required[r.Mod.Path] = r.Mod.Version
+	}
+	for _, r := range f.Replace {
+		if r.Old.Version != "" && r.Old.Version != required[r.Old.Path] {
+			continue
+		}
+		replacement := r.New.Path
+		if r.New.Version != "" {
+			replacement += "@" + r.New.Version
+		}
+		replaced[r.Old.Path] = replacement
+	}
+	return replaced, nil
+}
+
+// readModules reads the main module and the required modules from go.mod and
+// go.sum in dir, relative to the repository root, at a commit. Modules missing
+// from go.mod (as with go versions before 1.17) are taken from go.sum, at their
+// highest version. Without a go.mod, no modules are returned.
+func readModules(commit *object.Commit, dir string) (string, map[string]string, error) {
+	modules := make(map[string]string)
+	goMod, found, err := readCommitFile(commit, filepath.Join(dir, "go.mod"))
+	if err != nil || !found {
+		return "", modules, err
+	}
+	f, err := modfile.ParseLax("go.mod", []byte(goMod), nil)
+	if err != nil {
+		return "", nil, err
+	}
+	var mainModule string
+	if f.Module != nil {
+		mainModule = f.Module.Mod.Path
+	}
+	for _, r := range f.Require {
+		modules[r.Mod.Path] = r.Mod.Version
+	}
+
+	goSum, found, err := readCommitFile(commit, filepath.Join(dir, "go.sum"))
+	if err != nil || !found {
+		return mainModule, modules, err
+	}
+	sumVersions := make(map[string]string)
+	scanner := bufio.NewScanner(strings.NewReader(goSum))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) != 3 {
+			continue
+		}
+		path, version := fields[0], strings.TrimSuffix(fields[1], "/go.mod")
+		if _, required := modules[path]; !required {
+			sumVersions[path] = semver.Max(sumVersions[path], version)
+		}
+	}
+	for path, version := range sumVersions {
+		modules[path] = version
+	}
+	return mainModule, modules, scanner.Err()
+}
+
+var abbreviatedHash = regexp.MustCompile(`^[0-9a-f]{4,39}$`)
+
+// resolveCommit resolves a revision, including abbreviated commit hashes
+// which go-git does not resolve itself.
+func resolveCommit(repo *git.Repository, ref string) (*object.Commit, error) {
+	hash, err := repo.ResolveRevision(plumbing.Revision(ref))
+	if err == nil {
+		return repo.CommitObject(*hash)
+	}
+	if !abbreviatedHash.MatchString(ref) {
+		return nil, err
+	}
+	commits, iterErr := repo.CommitObjects()
+	if iterErr != nil {
+		return nil, iterErr
+	}
+	var match *object.Commit
+	iterErr = commits.ForEach(func(c *object.Commit) error {
+		if !strings.HasPrefix(c.Hash.String(), ref) {
+			return nil
+		}
+		if match != nil {
+			return fmt.Errorf("ambiguous commit hash %s", ref)
+		}
+		match = c
+		return nil
+	})
+	if iterErr != nil {
+		return nil, iterErr
+	}
+	if match == nil {
+		return nil, err
+	}
+	return match, nil
+}
+
+// findGoModDir returns the closest directory containing a go.mod, starting at dir.
+func findGoModDir(dir string) (string, error) {
+	for d := dir; ; d = filepath.Dir(d) {
+		if _, err := os.Stat(filepath.Join(d, "go.mod")); err == nil {
+			return d, nil
+		}
+		if filepath.Dir(d) == d {
+			return "", fmt.Errorf("no go.mod found in %s or its parents", dir)
+		}
+	}
+}
+
+// readFile reads a file, relative to the repository root, at the base ref.
+func (b *GitBase) readFile(path string) (string, bool, error) {
+	return readCommitFile(b.commit, path)
+}
+
+// readCommitFile reads a file, relative to the repository root, at a commit.
+func readCommitFile(commit *object.Commit, path string) (string, bool, error) {
+	f, err := commit.File(filepath.ToSlash(path))
+	if errors.Is(err, object.ErrFileNotFound) {
+		return "", false, nil
+	}
+	if err != nil {
+		return "", false, err
+	}
+	contents, err := f.Contents()
+	return contents, err == nil, err
+}
+
+// Change reports how the module of a result changed since the base ref, and
+// a description of the change. The license file of modules stored in the
+// repository, like the main module, is compared with the one at the ref.
+func (b *GitBase) Change(res LicenseResult) (ChangeKind, string, error) {
+	module := res.Module
+	if module == "" {
+		module = res.Library
+	}
+	if module != b.MainModule {
+		version, ok := b.Modules[module]
+		previous, wasReplaced := b.replaced[module]
+		current, isReplaced := b.currentReplaced[module]
+		switch {
+		case !ok:
+			return ModuleAdded, fmt.Sprintf("added at %s", res.Version), nil
+		case wasReplaced || isReplaced:
+			// replaced modules have the version of their replacement
+			if previous != current {
+				return ModuleUpgraded, fmt.Sprintf("replacement changed from %s to %s", replacementLabel(previous), replacementLabel(current)), nil
+			}
+		case version != res.Version:
+			return ModuleUpgraded, fmt.Sprintf("upgraded from %s to %s", version, res.Version), nil
+		}
+	}
+
+	if res.Path == "" {
+		return Unchanged, "", nil
+	}
+	rel, err := filepath.Rel(b.root, res.Path)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		// only license files in the repository can change without a new module version
+		return Unchanged, "", nil
+	}
+	previous, found, err := b.readFile(rel)
+	if err != nil {
+		return Unchanged, "", err
+	}
+	current, err := os.ReadFile(res.Path)
+	if err != nil {
+		return Unchanged, "", err
+	}
+	if !found || previous != string(current) {
+		return LicenseChanged, fmt.Sprintf("license file %s changed", filepath.ToSlash(rel)), nil
+	}
+	return Unchanged, "", nil
+}
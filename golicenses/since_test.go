@@ -0,0 +1,84 @@
+package golicenses
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	git "gopkg.in/src-d/go-git.v4"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+)
+
+func TestGitBase_Change(t *testing.T) {
+	dir := t.TempDir()
+	repo, err := git.PlainInit(dir, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wt, err := repo.Worktree()
+	if err != nil {
+		t.Fatal(err)
+	}
+	write := func(name, content string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := wt.Add(name); err != nil {
+			t.Fatal(err)
+		}
+	}
+	goMod := "module example.com/app\n\ngo 1.16\n\nrequire (\n\tgithub.com/foo/bar v1.0.0\n\tgithub.com/same/lib v0.3.0\n" +
+		"\tgithub.com/forked/lib v1.0.0\n\tgithub.com/moved/lib v1.0.0\n\tgithub.com/local/lib v0.1.0\n)\n\n" +
+		"replace github.com/forked/lib => github.com/me/lib v1.3.0\n\nreplace github.com/local/lib => ./local\n"
+	write("go.mod", goMod+"\nreplace github.com/moved/lib => github.com/me/moved v1.1.0\n")
+	write("go.sum", "github.com/foo/bar v1.0.0 h1:abc=\ngithub.com/indirect/dep v1.1.0/go.mod h1:def=\ngithub.com/indirect/dep v1.2.0 h1:ghi=\n")
+	write("LICENSE", "MIT License\n")
+	hash, err := wt.Commit("initial", &git.CommitOptions{Author: &object.Signature{Name: "test", Email: "test@example.com", When: time.Now()}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// working tree changes after the base commit
+	write("LICENSE", "Apache License 2.0\n")
+	write("go.mod", goMod+"\nreplace github.com/moved/lib => github.com/me/moved v1.2.0\n")
+
+	base, err := ReadGitBase(dir, "HEAD")
+	if err != nil {
+		t.Fatalf("ReadGitBase() error: %v", err)
+	}
+	if base.MainModule != "example.com/app" || base.Modules["github.com/indirect/dep"] != "v1.2.0" {
+		t.Errorf("ReadGitBase() = %+v", base)
+	}
+
+	root := base.root
+	for _, test := range []struct {
+		res      LicenseResult
+		expected ChangeKind
+	}{
+		{res: LicenseResult{Library: "github.com/same/lib", Module: "github.com/same/lib", Version: "v0.3.0"}, expected: Unchanged},
+		{res: LicenseResult{Library: "github.com/indirect/dep", Module: "github.com/indirect/dep", Version: "v1.2.0"}, expected: Unchanged},
+		{res: LicenseResult{Library: "github.com/foo/bar/pkg", Module: "github.com/foo/bar", Version: "v1.1.0"}, expected: ModuleUpgraded},
+		{res: LicenseResult{Library: "github.com/new/lib", Module: "github.com/new/lib", Version: "v1.0.0"}, expected: ModuleAdded},
+		// replaced modules have the version of their replacement
+		{res: LicenseResult{Library: "github.com/forked/lib", Module: "github.com/forked/lib", Version: "v1.3.0"}, expected: Unchanged},
+		{res: LicenseResult{Library: "github.com/local/lib", Module: "github.com/local/lib", Version: "v0.1.0"}, expected: Unchanged},
+		{res: LicenseResult{Library: "github.com/moved/lib", Module: "github.com/moved/lib", Version: "v1.2.0"}, expected: ModuleUpgraded},
+		{res: LicenseResult{Library: "example.com/app", Module: "example.com/app", Path: filepath.Join(root, "LICENSE")}, expected: LicenseChanged},
+	} {
+		kind, description, err := base.Change(test.res)
+		if err != nil {
+			t.Fatalf("Change(%s) error: %v", test.res.Library, err)
+		}
+		if kind != test.expected {
+			t.Errorf("Change(%s) = %s (%s), want %s", test.res.Library, kind, description, test.expected)
+		}
+	}
+
+	if _, err := ReadGitBase(dir, hash.String()[:7]); err != nil {
+		t.Errorf("ReadGitBase() with an abbreviated hash error: %v", err)
+	}
+	if _, err := ReadGitBase(dir, "does-not-exist"); err == nil {
+		t.Error("expected an error for an unknown ref")
+	}
+}
@@ -0,0 +1,116 @@
+package golicenses
+
+import (
+	"fmt"
+	"strings"
+
+	"golang.org/x/mod/semver"
+)
+
+// VersionConstraint restricts module versions by comparisons that must all
+// hold, e.g. ">= v1.2, < v2". A version without operator must match exactly.
+// A partial version stands for all versions of its major or minor release,
+// so "<= v1.4" includes v1.4.3 and "> v1" excludes v1.9.0.
+type VersionConstraint struct {
+	raw         string
+	comparisons []versionComparison
+}
+
+type versionComparison struct {
+	op      string
+	version string
+	// parts is the number of major, minor and patch numbers of the version.
+	parts int
+}
+
+// versionOperators are ordered so that two character operators are tried first.
+var versionOperators = []string{">=", "<=", "!=", ">", "<", "="}
+
+// ParseVersionConstraint parses comma separated version comparisons. An empty
+// constraint matches any version.
+func ParseVersionConstraint(s string) (VersionConstraint, error) {
+	c := VersionConstraint{raw: strings.TrimSpace(s)}
+	if c.raw == "" {
+		return c, nil
+	}
+	for _, part := range strings.Split(c.raw, ",") {
+		part = strings.TrimSpace(part)
+		op := "="
+		for _, o := range versionOperators {
+			if strings.HasPrefix(part, o) {
+				op, part = o, strings.TrimSpace(strings.TrimPrefix(part, o))
+				break
+			}
+		}
+		version := canonicalVersion(part)
+		if !semver.IsValid(version) {
+			return VersionConstraint{}, fmt.Errorf("bad version constraint %q: %q is not a semantic version", s, part)
+		}
+		c.comparisons = append(c.comparisons, versionComparison{op: op, version: version, parts: strings.Count(version, ".") + 1})
+	}
+	return c, nil
+}
+
+// Check reports whether a module version satisfies the constraint. Unknown
+// or invalid versions only satisfy an empty constraint.
+func (c VersionConstraint) Check(version string) bool {
+	if len(c.comparisons) == 0 {
+		return true
+	}
+	version = canonicalVersion(version)
+	if !semver.IsValid(version) {
+		return false
+	}
+	for _, cmp := range c.comparisons {
+		result := semver.Compare(cmp.truncate(version), cmp.version)
+		var ok bool
+		switch cmp.op {
+		case "=":
+			ok = result == 0
+		case "!=":
+			ok = result != 0
+		case ">":
+			ok = result > 0
+		case ">=":
+			ok = result >= 0
+		case "<":
+			ok = result < 0
+		case "<=":
+			ok = result <= 0
+		}
+		if !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// truncate shortens a version to the major or minor release of a partial
+// version it is compared with.
+func (c versionComparison) truncate(version string) string {
+	switch c.parts {
+	case 1:
+		return semver.Major(version)
+	case 2:
+		return semver.MajorMinor(version)
+	default:
+		return version
+	}
+}
+
+// IsEmpty reports whether the constraint matches any version.
+func (c VersionConstraint) IsEmpty() bool {
+	return len(c.comparisons) == 0
+}
+
+func (c VersionConstraint) String() string {
+	return c.raw
+}
+
+// canonicalVersion adds the "v" prefix that Go module versions have.
+func canonicalVersion(version string) string {
+	if version != "" && !strings.HasPrefix(version, "v") {
+		return "v" + version
+	}
+	return version
+}
@@ -0,0 +1,52 @@
+package golicenses
+
+import "testing"
+
+func TestVersionConstraint_Check(t *testing.T) {
+	tests := []struct {
+		constraint string
+		version    string
+		expected   bool
+	}{
+		{constraint: "", version: "", expected: true},
+		{constraint: "", version: "v0.1.0", expected: true},
+		{constraint: ">= v1.2", version: "v1.2.0", expected: true},
+		{constraint: ">= v1.2", version: "v1.10.1", expected: true},
+		{constraint: ">= v1.2", version: "v1.1.9", expected: false},
+		{constraint: ">= 1.2, < 2", version: "v1.5.0", expected: true},
+		{constraint: ">= 1.2, < 2", version: "v2.0.0", expected: false},
+		{constraint: "<= v1.4", version: "v1.4.0", expected: true},
+		{constraint: "<= v1.4", version: "v1.4.1", expected: true},
+		{constraint: "<= v1.4", version: "v1.4.3", expected: true},
+		{constraint: "<= v1.4", version: "v1.5.0", expected: false},
+		{constraint: "> v1.4", version: "v1.4.3", expected: false},
+		{constraint: "> v1.4", version: "v1.5.0", expected: true},
+		{constraint: "< v1.4", version: "v1.4.0-rc.1", expected: false},
+		{constraint: "< v1.4", version: "v1.3.9", expected: true},
+		{constraint: "= v1", version: "v1.9.0", expected: true},
+		{constraint: "> v1", version: "v1.9.0", expected: false},
+		{constraint: "<= v1.4.0", version: "v1.4.3", expected: false},
+		{constraint: "v1.4.1", version: "1.4.1", expected: true},
+		{constraint: "!= v1.4.1", version: "v1.4.1", expected: false},
+		{constraint: "> v0.0.0", version: "v0.0.0-20200402202327-879cb1424de0", expected: false},
+		{constraint: ">= v1.2", version: "", expected: false},
+		{constraint: ">= v1.2", version: "(devel)", expected: false},
+	}
+	for _, test := range tests {
+		c, err := ParseVersionConstraint(test.constraint)
+		if err != nil {
+			t.Fatalf("ParseVersionConstraint(%q) error: %v", test.constraint, err)
+		}
+		if actual := c.Check(test.version); actual != test.expected {
+			t.Errorf("%q.Check(%q) = %v, want %v", test.constraint, test.version, actual, test.expected)
+		}
+	}
+}
+
+func TestParseVersionConstraint_Errors(t *testing.T) {
+	for _, constraint := range []string{">= latest", "~> v1.2", ">= v1.2,"} {
+		if _, err := ParseVersionConstraint(constraint); err == nil {
+			t.Errorf("ParseVersionConstraint(%q): expected an error", constraint)
+		}
+	}
+}
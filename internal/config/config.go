@@ -6,6 +6,7 @@ import (
 	"strings"
 
 	"github.com/khulnasoft/go-licenses/golicenses"
+	"github.com/khulnasoft/go-licenses/golicenses/licenses"
 	"github.com/khulnasoft/go-licenses/golicenses/presenter"
 	"github.com/khulnasoft/go-licenses/internal"
 
@@ -22,12 +23,31 @@ type Application struct {
 	Forbid       StringArray `mapstructure:"forbid,deny"`
 	Permit       StringArray `mapstructure:"permit,allow"`
 	IgnorePkg    StringArray `mapstructure:"ignore-packages"`
+	ForbidTypes  StringArray `mapstructure:"forbid-types"`
+	PermitTypes  StringArray `mapstructure:"permit-types"`
+	// Rules are evaluated after the permit/forbid lists, see RuleMatch.
+	Rules []golicenses.Rule `mapstructure:"rules"`
+	// RuleMatch is "first" or "most-specific" (see golicenses.MatchMode).
+	RuleMatch string `mapstructure:"rule-match"`
 	// For CLI compatibility
 	Format              string  `mapstructure:"format"`
 	TemplateFile        string  `mapstructure:"template-file"`
 	Strict              bool    `mapstructure:"strict"`
 	Summary             bool    `mapstructure:"summary"`
 	ConfidenceThreshold float64 `mapstructure:"confidence-threshold"`
+	Classifier          string  `mapstructure:"classifier"`
+	// ClassifierPlugin configures the executable used by the "plugin" classifier.
+	ClassifierPlugin licenses.PluginConfig `mapstructure:"classifier-plugin"`
+	// CustomLicenses are license texts to identify in addition to the license database.
+	CustomLicenses licenses.CustomLicensesConfig `mapstructure:"custom-licenses"`
+	// LicenseDB is a license database archive to use instead of the embedded one.
+	LicenseDB string `mapstructure:"license-db"`
+	// Overrides manually assert the license of modules.
+	Overrides []golicenses.Override `mapstructure:"overrides"`
+	// Exceptions accept violations of the rules until they expire.
+	Exceptions []golicenses.Exception `mapstructure:"exceptions"`
+	// ExceptionExpiryWarning is how many days before expiry check warns about an exception.
+	ExceptionExpiryWarning int `mapstructure:"exception-expiry-warning"`
 }
 
 type StringArray []string
@@ -49,7 +69,7 @@ func (a *StringArray) UnmarshalYAML(unmarshal func(interface{}) error) error {
 }
 
 func setNonCliDefaultValues(v *viper.Viper) {
-	// TODO
+	v.SetDefault("exception-expiry-warning", 30)
 }
 
 func LoadConfigFromFile(v *viper.Viper, configPath string) (*Application, error) {
@@ -80,8 +100,28 @@ func LoadConfigFromFile(v *viper.Viper, configPath string) (*Application, error)
 
 func (cfg *Application) Build() error {
 	// validate rule input
-	if len(cfg.Forbid) > 0 && len(cfg.Permit) > 0 {
-		return fmt.Errorf("'forbid'/'deny' and 'permit'/'allow' options are mutually exclusive")
+	if _, err := cfg.RuleList(); err != nil {
+		return err
+	}
+	if _, err := golicenses.ParseMatchMode(cfg.RuleMatch); err != nil {
+		return err
+	}
+
+	if cfg.Classifier != "" && !contains(golicenses.ClassifierBackends, cfg.Classifier) {
+		return fmt.Errorf("bad --classifier value '%s' (options=%v)", cfg.Classifier, golicenses.ClassifierBackends)
+	}
+	if cfg.Classifier == golicenses.PluginClassifier && len(cfg.ClassifierPlugin.Command) == 0 {
+		return fmt.Errorf("the plugin classifier requires 'classifier-plugin.command' to be set")
+	}
+
+	if err := cfg.CustomLicenses.Validate(); err != nil {
+		return err
+	}
+	if _, err := golicenses.NewOverrides(cfg.Overrides...); err != nil {
+		return err
+	}
+	if _, err := golicenses.NewExceptions(cfg.Exceptions...); err != nil {
+		return err
 	}
 
 	// set the presenter
@@ -94,24 +134,48 @@ func (cfg *Application) Build() error {
 	return nil
 }
 
-// Action returns the license rule action based on Permit/Forbid config.
-func (cfg *Application) Action() golicenses.Action {
+// RuleList returns the rules to evaluate in order: the rules list, followed by
+// the forbid, permit, forbid-types and permit-types lists (see Rules.Decide
+// for their precedence).
+func (cfg *Application) RuleList() ([]golicenses.Rule, error) {
+	var list []golicenses.Rule
+	add := func(rules []golicenses.Rule, err error) error {
+		list = append(list, rules...)
+		return err
+	}
+	if err := add(golicenses.NewRuleList(cfg.Rules...)); err != nil {
+		return nil, err
+	}
+	if len(cfg.Forbid) > 0 {
+		if err := add(golicenses.LicenseRules(golicenses.DenyAction, cfg.Forbid)); err != nil {
+			return nil, err
+		}
+	}
 	if len(cfg.Permit) > 0 {
-		return golicenses.AllowAction
-	} else if len(cfg.Forbid) > 0 {
-		return golicenses.DenyAction
+		if err := add(golicenses.LicenseRules(golicenses.AllowAction, cfg.Permit)); err != nil {
+			return nil, err
+		}
+	}
+	if len(cfg.ForbidTypes) > 0 {
+		if err := add(golicenses.TypeRules(golicenses.DenyAction, cfg.ForbidTypes)); err != nil {
+			return nil, err
+		}
 	}
-	return golicenses.UnknownAction
+	if len(cfg.PermitTypes) > 0 {
+		if err := add(golicenses.TypeRules(golicenses.AllowAction, cfg.PermitTypes)); err != nil {
+			return nil, err
+		}
+	}
+	return list, nil
 }
 
-// Patterns returns the license patterns based on Permit/Forbid config.
-func (cfg *Application) Patterns() []string {
-	if len(cfg.Permit) > 0 {
-		return cfg.Permit
-	} else if len(cfg.Forbid) > 0 {
-		return cfg.Forbid
+func contains(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
 	}
-	return nil
+	return false
 }
 
 func readConfig(v *viper.Viper, configPath string) error {
@@ -1,9 +1,14 @@
 package config
 
 import (
-	"github.com/spf13/viper"
 	"os"
+	"reflect"
 	"testing"
+	"time"
+
+	"github.com/khulnasoft/go-licenses/golicenses"
+	"github.com/khulnasoft/go-licenses/golicenses/licenses"
+	"github.com/spf13/viper"
 )
 
 func TestLoadConfigFromFile_FileNotFound(t *testing.T) {
@@ -24,3 +29,114 @@ func TestLoadConfigFromFile_InvalidFormat(t *testing.T) {
 		t.Error("expected error for invalid config format, got nil")
 	}
 }
+
+func TestApplication_BuildRules(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     Application
+		wantErr bool
+	}{
+		{name: "forbid types", cfg: Application{Output: "text", ForbidTypes: StringArray{"restricted"}}},
+		{name: "with license rules", cfg: Application{Output: "text", Permit: StringArray{"MIT"}, ForbidTypes: StringArray{"reciprocal"}}},
+		{name: "forbid and permit types", cfg: Application{Output: "text", ForbidTypes: StringArray{"restricted"}, PermitTypes: StringArray{"notice"}}},
+		{name: "forbid and permit", cfg: Application{Output: "text", Forbid: StringArray{"AGPL.*"}, Permit: StringArray{"GPL.*"}}},
+		{name: "bad type", cfg: Application{Output: "text", PermitTypes: StringArray{"copyleft"}}, wantErr: true},
+		{name: "rules", cfg: Application{Output: "text", Rules: []golicenses.Rule{{License: "AGPL.*", Action: "deny"}, {Action: "review"}}, RuleMatch: "most-specific"}},
+		{name: "bad rule", cfg: Application{Output: "text", Rules: []golicenses.Rule{{License: "AGPL.*"}}}, wantErr: true},
+		{name: "bad rule match", cfg: Application{Output: "text", RuleMatch: "last"}, wantErr: true},
+		{name: "custom license without type", cfg: Application{Output: "text", CustomLicenses: licenses.CustomLicensesConfig{Licenses: []licenses.CustomLicenseConfig{{Name: "ACME-EULA"}}}}, wantErr: true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.cfg.Build()
+			if (err != nil) != test.wantErr {
+				t.Errorf("Build() error = %v, wantErr %v", err, test.wantErr)
+			}
+		})
+	}
+}
+
+func TestApplication_RuleList(t *testing.T) {
+	scopedAllow := golicenses.Rule{Module: "github.com/foo/bar", Version: ">= v1.2", License: "LGPL-.*", Action: "allow"}
+	denyLGPL := golicenses.Rule{License: "LGPL-.*", Action: "deny"}
+	tests := []struct {
+		name  string
+		match string
+		rules []golicenses.Rule
+	}{
+		{name: "first match", match: "first", rules: []golicenses.Rule{scopedAllow, denyLGPL}},
+		{name: "most specific", match: "most-specific", rules: []golicenses.Rule{denyLGPL, scopedAllow}},
+	}
+	results := []golicenses.LicenseResult{
+		{Library: "github.com/foo/bar", Module: "github.com/foo/bar", Version: "v1.3.0", License: "LGPL-2.1"},
+		{Library: "github.com/foo/bar/old", Module: "github.com/foo/bar", Version: "v1.1.0", License: "LGPL-2.1"},
+		{Library: "github.com/foo/baz", Module: "github.com/foo/baz", Version: "v1.3.0", License: "LGPL-2.1"},
+		{Library: "github.com/foo/mit", Module: "github.com/foo/mit", Version: "v1.0.0", License: "MIT"},
+		{Library: "github.com/foo/gpl", Module: "github.com/foo/gpl", Version: "v1.0.0", License: "GPL-2.0"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cfg := Application{Output: "text", Permit: StringArray{"MIT"}, Rules: test.rules, RuleMatch: test.match}
+			if err := cfg.Build(); err != nil {
+				t.Fatalf("Build() error = %v", err)
+			}
+			list, err := cfg.RuleList()
+			if err != nil {
+				t.Fatalf("RuleList() error = %v", err)
+			}
+			match, err := golicenses.ParseMatchMode(cfg.RuleMatch)
+			if err != nil {
+				t.Fatalf("ParseMatchMode() error = %v", err)
+			}
+			rules, err := golicenses.NewOrderedRules(match, list)
+			if err != nil {
+				t.Fatalf("NewOrderedRules() error = %v", err)
+			}
+			violations, err := rules.ViolationsAt(time.Now(), results...)
+			if err != nil {
+				t.Fatalf("ViolationsAt() error = %v", err)
+			}
+			var denied []string
+			for _, v := range violations {
+				denied = append(denied, v.Result.Library)
+			}
+			want := []string{"github.com/foo/bar/old", "github.com/foo/baz", "github.com/foo/gpl"}
+			if !reflect.DeepEqual(denied, want) {
+				t.Errorf("denied %v, want %v", denied, want)
+			}
+		})
+	}
+}
+
+func TestApplication_RuleListForbidWithCatchAll(t *testing.T) {
+	cfg := Application{Output: "text", Forbid: StringArray{"AGPL-3.0"}, Rules: []golicenses.Rule{{Action: "review"}}}
+	if err := cfg.Build(); err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+	list, err := cfg.RuleList()
+	if err != nil {
+		t.Fatalf("RuleList() error = %v", err)
+	}
+	rules, err := golicenses.NewOrderedRules(golicenses.FirstMatch, list)
+	if err != nil {
+		t.Fatalf("NewOrderedRules() error = %v", err)
+	}
+	results := []golicenses.LicenseResult{
+		{Library: "github.com/foo/agpl", Module: "github.com/foo/agpl", Version: "v1.0.0", License: "AGPL-3.0"},
+		{Library: "github.com/foo/mit", Module: "github.com/foo/mit", Version: "v1.0.0", License: "MIT"},
+	}
+	violations, err := rules.ViolationsAt(time.Now(), results...)
+	if err != nil {
+		t.Fatalf("ViolationsAt() error = %v", err)
+	}
+	want := []golicenses.Violation{
+		{Result: results[0], Kind: golicenses.LicenseRule, Action: golicenses.DenyAction, Rule: "AGPL-3.0", RuleID: "deny/license/AGPL-3.0", Severity: golicenses.ErrorSeverity},
+		{Result: results[1], Kind: golicenses.CatchAllRule, Action: golicenses.ReviewAction, RuleID: "review/catch-all", Severity: golicenses.WarnSeverity},
+	}
+	if !reflect.DeepEqual(violations, want) {
+		t.Errorf("violations = %+v, want %+v", violations, want)
+	}
+	if allowed, _, _ := rules.EvaluateAt(time.Now(), results...); allowed {
+		t.Error("EvaluateAt() allowed the forbidden license")
+	}
+}